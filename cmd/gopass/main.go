@@ -59,6 +59,6 @@ func main() {
 
 	if err := cli.Run(ctx, commandConfig, args); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %s.\n", err)
-		os.Exit(1)
+		os.Exit(cli.ExitCode(err))
 	}
 }
@@ -3,6 +3,7 @@ module github.com/aviau/gopass
 go 1.17
 
 require (
+	github.com/boombuler/barcode v1.0.1
 	github.com/mgutz/ansi v0.0.0-20200706080929-d51e80ef957d
 	github.com/pquerna/otp v1.3.0
 	github.com/stretchr/testify v1.8.1
@@ -12,7 +13,6 @@ require (
 
 require (
 	github.com/BurntSushi/toml v0.3.1 // indirect
-	github.com/boombuler/barcode v1.0.1 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.16 // indirect
@@ -18,7 +18,11 @@
 // Package cli implements the gopass CLI.
 package cli
 
-import "context"
+import (
+	"context"
+
+	"github.com/aviau/gopass/internal/clipboard"
+)
 
 // Run parses the arguments and executes the gopass CLI
 func Run(ctx context.Context, cfg CommandConfig, cmdAndArgs []string) error {
@@ -29,11 +33,13 @@ func Run(ctx context.Context, cfg CommandConfig, cmdAndArgs []string) error {
 
 	switch cmd {
 	case "show":
-		return execShow(cfg, cmdAndArgs[1:])
+		return execShow(ctx, cfg, cmdAndArgs[1:])
+	case "otp":
+		return execOtp(ctx, cfg, cmdAndArgs[1:])
 	case "edit":
-		return execEdit(cfg, cmdAndArgs[1:])
+		return execEdit(ctx, cfg, cmdAndArgs[1:])
 	case "insert", "add":
-		return execInsert(cfg, cmdAndArgs[1:])
+		return execInsert(ctx, cfg, cmdAndArgs[1:])
 	case "find", "ls", "search", "list":
 		return execFind(cfg, cmdAndArgs[1:])
 	case "alfred":
@@ -41,25 +47,31 @@ func Run(ctx context.Context, cfg CommandConfig, cmdAndArgs []string) error {
 	case "":
 		return execFind(cfg, cmdAndArgs)
 	case "grep":
-		return execGrep(cfg, cmdAndArgs[1:])
+		return execGrep(ctx, cfg, cmdAndArgs[1:])
 	case "cp", "copy":
-		return execCp(cfg, cmdAndArgs[1:])
+		return execCp(ctx, cfg, cmdAndArgs[1:])
 	case "mv", "rename":
-		return execMv(cfg, cmdAndArgs[1:])
+		return execMv(ctx, cfg, cmdAndArgs[1:])
 	case "rm", "remove", "delete":
-		return execRm(cfg, cmdAndArgs[1:])
+		return execRm(ctx, cfg, cmdAndArgs[1:])
 	case "generate":
-		return execGenerate(cfg, cmdAndArgs[1:])
+		return execGenerate(ctx, cfg, cmdAndArgs[1:])
 	case "git":
-		return execGit(cfg, cmdAndArgs[1:])
+		return execGit(ctx, cfg, cmdAndArgs[1:])
 	case "help", "-h", "--help":
 		return execHelp(cfg)
+	case "recipients":
+		return execRecipients(ctx, cfg, cmdAndArgs[1:])
+	case "fsck":
+		return execFsck(ctx, cfg, cmdAndArgs[1:])
 	case "init":
-		return execInit(cfg, cmdAndArgs[1:])
+		return execInit(ctx, cfg, cmdAndArgs[1:])
+	case clipboard.ClearHelperCommand:
+		return clipboard.RunClearHelper(cfg.ReaderInput())
 	case "version":
 		return execVersion(cfg)
 	default:
-		return execShow(cfg, cmdAndArgs)
+		return execShow(ctx, cfg, cmdAndArgs)
 	}
 
 }
@@ -21,6 +21,7 @@ package clitest
 import (
 	"bytes"
 	"context"
+	"io"
 	"os"
 	"testing"
 
@@ -32,6 +33,7 @@ import (
 // cliTest allows for testing the CLI without a TTY.
 type cliTest struct {
 	passwordStoreTest *storetest.PasswordStoreTest
+	clipboard         *memoryClipboard
 }
 
 func NewCliTest(t *testing.T) *cliTest {
@@ -39,6 +41,7 @@ func NewCliTest(t *testing.T) *cliTest {
 
 	cliTest := cliTest{
 		passwordStoreTest: passwordStoreTest,
+		clipboard:         &memoryClipboard{},
 	}
 
 	return &cliTest
@@ -48,6 +51,11 @@ func (cliTest *cliTest) PasswordStore() *store.PasswordStore {
 	return cliTest.passwordStoreTest.PasswordStore
 }
 
+// Clipboard returns what commands copied to the clipboard.
+func (cliTest *cliTest) Clipboard() string {
+	return cliTest.clipboard.content
+}
+
 type runResult struct {
 	Stdout *bytes.Buffer
 	Stderr *bytes.Buffer
@@ -55,7 +63,9 @@ type runResult struct {
 
 func (cliTest *cliTest) Run(args []string, runOptionFns ...RunOption) (*runResult, error) {
 	// Create RunOptions
-	runOptions := &runOptions{}
+	runOptions := &runOptions{
+		ctx: context.TODO(),
+	}
 	for _, fn := range runOptionFns {
 		fn(runOptions)
 	}
@@ -64,16 +74,22 @@ func (cliTest *cliTest) Run(args []string, runOptionFns ...RunOption) (*runResul
 	stdout := &bytes.Buffer{}
 	stderr := &bytes.Buffer{}
 
+	var readerInput io.Reader = os.Stdin
+	if runOptions.input != nil {
+		readerInput = runOptions.input
+	}
+
 	testConfig := &testCommandConfig{
 		passwordStore: cliTest.PasswordStore(),
 		runOptions:    runOptions,
 		writerOutput:  stdout,
 		writerError:   stderr,
-		readerInput:   os.Stdin,
+		readerInput:   readerInput,
+		clipboard:     cliTest.clipboard,
 	}
 
 	// Run the command
-	err := cli.Run(context.TODO(), testConfig, args)
+	err := cli.Run(runOptions.ctx, testConfig, args)
 
 	// Results
 	runResult := &runResult{
@@ -21,6 +21,7 @@ import (
 	"io"
 	"time"
 
+	"github.com/aviau/gopass/internal/clipboard"
 	"github.com/aviau/gopass/pkg/store"
 )
 
@@ -31,6 +32,7 @@ type testCommandConfig struct {
 	writerOutput  io.Writer
 	writerError   io.Writer
 	readerInput   io.Reader
+	clipboard     *memoryClipboard
 }
 
 func (cfg *testCommandConfig) PasswordStore() *store.PasswordStore {
@@ -66,3 +68,31 @@ func (cfg *testCommandConfig) Now() time.Time {
 	}
 	return time.Now()
 }
+
+// ClipTime is zero so that tests never start the clipboard clear helper.
+func (cfg *testCommandConfig) ClipTime() time.Duration {
+	return 0
+}
+
+func (cfg *testCommandConfig) Clipboard() (clipboard.Provider, error) {
+	return cfg.clipboard, nil
+}
+
+// memoryClipboard is a clipboard.Provider that keeps the clipboard in
+// memory.
+type memoryClipboard struct {
+	content string
+}
+
+func (c *memoryClipboard) Name() string {
+	return "memory"
+}
+
+func (c *memoryClipboard) Copy(s string) error {
+	c.content = s
+	return nil
+}
+
+func (c *memoryClipboard) Paste() (string, error) {
+	return c.content, nil
+}
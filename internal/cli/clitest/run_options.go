@@ -17,12 +17,18 @@
 
 package clitest
 
-import "time"
+import (
+	"context"
+	"io"
+	"time"
+)
 
 // runOptions contain *optitonal* parameters for Run().
 type runOptions struct {
 	editFunc func(string) (string, error)
 	nowFunc  func() time.Time
+	ctx      context.Context
+	input    io.Reader
 }
 
 type RunOption func(*runOptions)
@@ -46,3 +52,16 @@ func WithFixedTime(t time.Time) RunOption {
 		},
 	)
 }
+
+func WithContext(ctx context.Context) RunOption {
+	return func(opts *runOptions) {
+		opts.ctx = ctx
+	}
+}
+
+// WithInput makes commands read their standard input from input.
+func WithInput(input io.Reader) RunOption {
+	return func(opts *runOptions) {
+		opts.input = input
+	}
+}
@@ -18,23 +18,29 @@
 package cli
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"io/ioutil"
 	"path/filepath"
 	"strings"
+
+	gopass_store "github.com/aviau/gopass/pkg/store"
 )
 
 // execCp runs the "cp" command.
-func execCp(cfg CommandConfig, args []string) error {
+func execCp(ctx context.Context, cfg CommandConfig, args []string) error {
 	var recursive, r bool
 	var force, f bool
+	var noReencrypt bool
 	var help, h bool
 
 	fs := flag.NewFlagSet("cp", flag.ContinueOnError)
 	fs.SetOutput(ioutil.Discard)
 
-	fs.Usage = func() { fmt.Fprintln(cfg.WriterOutput(), "Usage: gopass cp old-path new-path") }
+	fs.Usage = func() {
+		fmt.Fprintln(cfg.WriterOutput(), "Usage: gopass cp [--recursive,-r] [--force,-f] [--no-reencrypt] old-path new-path")
+	}
 
 	fs.BoolVar(&help, "help", false, "")
 	fs.BoolVar(&h, "h", false, "")
@@ -45,6 +51,8 @@ func execCp(cfg CommandConfig, args []string) error {
 	fs.BoolVar(&force, "force", false, "")
 	fs.BoolVar(&f, "f", false, "")
 
+	fs.BoolVar(&noReencrypt, "no-reencrypt", false, "")
+
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
@@ -58,6 +66,7 @@ func execCp(cfg CommandConfig, args []string) error {
 	force = force || f
 
 	store := cfg.PasswordStore()
+	reencrypt := gopass_store.WithReencrypt(!noReencrypt)
 
 	source := fs.Arg(0)
 	dest := fs.Arg(1)
@@ -76,11 +85,11 @@ func execCp(cfg CommandConfig, args []string) error {
 
 		if destAlreadyExists, _ := store.ContainsPassword(dest); destAlreadyExists {
 			if !force {
-				return fmt.Errorf("destination \"%s\" already exists. Use -f to override", dest)
+				return newCommandError(gopass_store.ErrAlreadyExists, "destination \"%s\" already exists. Use -f to override", dest)
 			}
 		}
 
-		if err := store.CopyPassword(source, dest); err != nil {
+		if err := store.CopyPasswordContext(ctx, source, dest, reencrypt); err != nil {
 			return err
 		}
 
@@ -94,7 +103,7 @@ func execCp(cfg CommandConfig, args []string) error {
 			return fmt.Errorf("\"%s\" is a directory, use -r to copy recursively", source)
 		}
 
-		if err := store.CopyDirectory(source, dest); err != nil {
+		if err := store.CopyDirectoryContext(ctx, source, dest, reencrypt); err != nil {
 			return err
 		}
 
@@ -102,5 +111,5 @@ func execCp(cfg CommandConfig, args []string) error {
 		return nil
 	}
 
-	return fmt.Errorf("could not find source \"%s\" to copy", source)
+	return notFoundError(store, source, "could not find source \"%s\" to copy", source)
 }
@@ -18,13 +18,14 @@
 package cli
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"io/ioutil"
 )
 
 // execEdit runs the "edit" command.
-func execEdit(cfg CommandConfig, args []string) error {
+func execEdit(ctx context.Context, cfg CommandConfig, args []string) error {
 	var help, h bool
 
 	fs := flag.NewFlagSet("edit", flag.ContinueOnError)
@@ -52,7 +53,7 @@ func execEdit(cfg CommandConfig, args []string) error {
 	decryptedPassword := ""
 	if containsPasword, _ := store.ContainsPassword(passName); containsPasword {
 		var err error
-		decryptedPassword, err = store.GetPassword(passName)
+		decryptedPassword, err = store.GetPasswordContext(ctx, passName)
 		if err != nil {
 			return err
 		}
@@ -64,7 +65,7 @@ func execEdit(cfg CommandConfig, args []string) error {
 		return fmt.Errorf("could not edit password: %w", err)
 	}
 
-	if err := store.InsertPassword(passName, editedPassword); err != nil {
+	if err := store.InsertPasswordContext(ctx, passName, editedPassword); err != nil {
 		return err
 	}
 
@@ -0,0 +1,87 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+
+	gopass_store "github.com/aviau/gopass/pkg/store"
+)
+
+// execFsck runs the "fsck" command.
+func execFsck(ctx context.Context, cfg CommandConfig, args []string) error {
+	var fix, f bool
+	var help, h bool
+
+	fs := flag.NewFlagSet("fsck", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+
+	fs.Usage = func() {
+		fmt.Fprintln(cfg.WriterOutput(), "Usage: gopass fsck [--fix,-f]")
+	}
+
+	fs.BoolVar(&help, "help", false, "")
+	fs.BoolVar(&h, "h", false, "")
+
+	fs.BoolVar(&fix, "fix", false, "")
+	fs.BoolVar(&f, "f", false, "")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if help || h {
+		fs.Usage()
+		return nil
+	}
+
+	fix = fix || f
+
+	store := cfg.PasswordStore()
+
+	problems, err := store.FsckContext(ctx, gopass_store.WithFix(fix))
+	for _, problem := range problems {
+		fmt.Fprintln(cfg.WriterOutput(), problem)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(problems) == 0 {
+		fmt.Fprintln(cfg.WriterOutput(), "No problems found.")
+		return nil
+	}
+
+	fixed := 0
+	for _, problem := range problems {
+		if problem.Fixed {
+			fixed++
+		}
+	}
+
+	fmt.Fprintf(cfg.WriterOutput(), "%d problems found, %d fixed.\n", len(problems), fixed)
+
+	if fixed < len(problems) {
+		return fmt.Errorf("%d problems were not fixed", len(problems)-fixed)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package cli_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aviau/gopass/internal/cli/clitest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFsckDashDashHelp(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	result, err := cliTest.Run([]string{"fsck", "--help"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "", result.Stderr.String())
+	assert.True(t, strings.Contains(result.Stdout.String(), "Usage: gopass fsck [--fix,-f]"))
+}
+
+func TestFsckNoProblems(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	if err := cliTest.PasswordStore().InsertPassword("test.com", "password"); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := cliTest.Run([]string{"fsck"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "", result.Stderr.String())
+	assert.Equal(t, "No problems found.\n", result.Stdout.String())
+}
+
+func TestFsckFix(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	if err := cliTest.PasswordStore().InsertPassword("test.com", "password"); err != nil {
+		t.Fatal(err)
+	}
+
+	passwordPath := filepath.Join(cliTest.PasswordStore().Path, "test.com.gpg")
+	if err := os.Chmod(passwordPath, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := cliTest.Run([]string{"fsck"})
+
+	assert.NotNil(t, err)
+	assert.True(t, strings.Contains(result.Stdout.String(), "\"test.com.gpg\" is accessible by other users: mode is 0644\n"))
+	assert.True(t, strings.Contains(result.Stdout.String(), "1 problems found, 0 fixed.\n"))
+
+	result, err = cliTest.Run([]string{"fsck", "--fix"})
+
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(result.Stdout.String(), "1 problems found, 1 fixed.\n"))
+
+	fileInfo, err := os.Stat(passwordPath)
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), fileInfo.Mode().Perm())
+}
@@ -18,9 +18,12 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"strconv"
 
 	"github.com/aviau/gopass/internal/pwgen"
@@ -28,16 +31,21 @@ import (
 )
 
 // execGenerate runs the "generate" command.
-func execGenerate(cfg CommandConfig, args []string) error {
+func execGenerate(ctx context.Context, cfg CommandConfig, args []string) error {
 	var noSymbols, n bool
 	var force, f bool
 	var help, h bool
+	var words int
+	var separator string
+	var capitalize, digit, symbol bool
+	var wordlistFile string
 
 	fs := flag.NewFlagSet("generate", flag.ContinueOnError)
 	fs.SetOutput(ioutil.Discard)
 
 	fs.Usage = func() {
-		fmt.Fprintln(cfg.WriterOutput(), `Usage: gopass generate [--no-symbols,-n] [--force,-f] pass-name pass-length`)
+		fmt.Fprintln(cfg.WriterOutput(), `Usage: gopass generate [--no-symbols,-n] [--force,-f] pass-name pass-length
+       gopass generate --words=count [--separator=separator] [--capitalize] [--digit] [--symbol] [--wordlist=file] [--force,-f] pass-name`)
 	}
 
 	fs.BoolVar(&help, "help", false, "")
@@ -49,6 +57,13 @@ func execGenerate(cfg CommandConfig, args []string) error {
 	fs.BoolVar(&force, "force", false, "")
 	fs.BoolVar(&f, "f", false, "")
 
+	fs.IntVar(&words, "words", 0, "")
+	fs.StringVar(&separator, "separator", "-", "")
+	fs.BoolVar(&capitalize, "capitalize", false, "")
+	fs.BoolVar(&digit, "digit", false, "")
+	fs.BoolVar(&symbol, "symbol", false, "")
+	fs.StringVar(&wordlistFile, "wordlist", "", "")
+
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
@@ -63,6 +78,27 @@ func execGenerate(cfg CommandConfig, args []string) error {
 
 	passName := fs.Arg(0)
 
+	if words < 0 {
+		return errors.New("--words must be positive")
+	}
+
+	// Read the word list before anything else, so that mistakes are found
+	// early.
+	var wordlist []string
+	if words > 0 {
+		wordlist = pwgen.EFFWordlist()
+	}
+	if words > 0 && wordlistFile != "" {
+		file, err := os.Open(wordlistFile)
+		if err != nil {
+			return fmt.Errorf("could not read the word list: %w", err)
+		}
+		defer file.Close()
+		if wordlist, err = pwgen.ParseWordlist(file); err != nil {
+			return fmt.Errorf("could not read the word list: %w", err)
+		}
+	}
+
 	store := cfg.PasswordStore()
 
 	if containsPassword, _ := store.ContainsPassword(passName); containsPassword && !force {
@@ -71,22 +107,38 @@ func execGenerate(cfg CommandConfig, args []string) error {
 		}
 	}
 
-	passLength, err := strconv.ParseInt(fs.Arg(1), 0, 64)
-	if err != nil {
-		return fmt.Errorf("second argument must be an int, got \"%s\"", fs.Arg(1))
-	}
+	var password string
+	var entropy float64
+
+	if words > 0 {
+		password, entropy = pwgen.Passphrase(wordlist, pwgen.PassphraseOptions{
+			Words:      words,
+			Separator:  separator,
+			Capitalize: capitalize,
+			Digit:      digit,
+			Symbol:     symbol,
+		})
+	} else {
+		passLength, err := strconv.ParseInt(fs.Arg(1), 0, 64)
+		if err != nil {
+			return fmt.Errorf("second argument must be an int, got \"%s\"", fs.Arg(1))
+		}
 
-	runes := append(pwgen.Alpha, pwgen.Num...)
-	if !noSymbols {
-		runes = append(runes, pwgen.Symbols...)
-	}
+		runes := append(pwgen.Alpha, pwgen.Num...)
+		if !noSymbols {
+			runes = append(runes, pwgen.Symbols...)
+		}
 
-	password := pwgen.RandSeq(int(passLength), runes)
+		password = pwgen.RandSeq(int(passLength), runes)
+	}
 
-	if err := store.InsertPassword(passName, password); err != nil {
+	if err := store.InsertPasswordContext(ctx, passName, password); err != nil {
 		return err
 	}
 
 	fmt.Fprintf(cfg.WriterOutput(), "Password \"%s\" added to the store.\n", passName)
+	if words > 0 {
+		fmt.Fprintf(cfg.WriterOutput(), "The passphrase has %d words and about %.0f bits of entropy.\n", words, entropy)
+	}
 	return nil
 }
@@ -18,10 +18,13 @@
 package cli_test
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/aviau/gopass/internal/cli/clitest"
+	"github.com/aviau/gopass/internal/pwgen"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -46,3 +49,66 @@ func TestGenerateDashH(t *testing.T) {
 	assert.Equal(t, "", result.Stderr.String())
 	assert.True(t, strings.Contains(result.Stdout.String(), "Usage: gopass generate"))
 }
+
+func TestGenerateWords(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	result, err := cliTest.Run([]string{"generate", "--words=6", "--separator= ", "test.com"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "", result.Stderr.String())
+	assert.Equal(t, "Password \"test.com\" added to the store.\nThe passphrase has 6 words and about 78 bits of entropy.\n", result.Stdout.String())
+
+	password, err := cliTest.PasswordStore().GetPassword("test.com")
+	assert.Nil(t, err)
+
+	words := strings.Split(password, " ")
+	assert.Len(t, words, 6)
+	for _, word := range words {
+		assert.Contains(t, pwgen.EFFWordlist(), word)
+	}
+}
+
+func TestGenerateWordlist(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	wordlistPath := filepath.Join(t.TempDir(), "wordlist.txt")
+	if err := os.WriteFile(wordlistPath, []byte("alpha\nbravo\ncharlie\ndelta\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := cliTest.Run([]string{"generate", "--words=3", "--wordlist", wordlistPath, "--capitalize", "test.com"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Password \"test.com\" added to the store.\nThe passphrase has 3 words and about 6 bits of entropy.\n", result.Stdout.String())
+
+	password, err := cliTest.PasswordStore().GetPassword("test.com")
+	assert.Nil(t, err)
+
+	for _, word := range strings.Split(password, "-") {
+		assert.Contains(t, []string{"Alpha", "Bravo", "Charlie", "Delta"}, word)
+	}
+
+	_, err = cliTest.Run([]string{"generate", "--words=3", "--wordlist", filepath.Join(t.TempDir(), "missing.txt"), "test.com"})
+	assert.Error(t, err)
+}
+
+func TestGenerateWithoutGit(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	// The store has no git repository, as when made by "pass init" without
+	// "pass git init".
+	assert.False(t, cliTest.PasswordStore().UsesGit)
+
+	result, err := cliTest.Run([]string{"generate", "test.com", "10"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "", result.Stderr.String())
+	assert.Equal(t, "Password \"test.com\" added to the store.\n", result.Stdout.String())
+
+	_, err = os.Stat(filepath.Join(cliTest.PasswordStore().Path, ".git"))
+	assert.True(t, os.IsNotExist(err))
+}
@@ -18,11 +18,12 @@
 package cli
 
 import (
+	"context"
 	"os/exec"
 )
 
 // execGit runs the "git" command.
-func execGit(cfg CommandConfig, args []string) error {
+func execGit(ctx context.Context, cfg CommandConfig, args []string) error {
 	store := cfg.PasswordStore()
 
 	gitArgs := []string{
@@ -31,13 +32,13 @@ func execGit(cfg CommandConfig, args []string) error {
 
 	gitArgs = append(gitArgs, args...)
 
-	git := exec.Command(
+	git := exec.CommandContext(
+		ctx,
 		"git",
 		gitArgs...)
 
 	git.Stdout = cfg.WriterOutput()
 	git.Stderr = cfg.WriterError()
 	git.Stdin = cfg.ReaderInput()
-	git.Run()
-	return nil
+	return git.Run()
 }
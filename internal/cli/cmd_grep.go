@@ -18,6 +18,7 @@
 package cli
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"regexp"
@@ -27,7 +28,7 @@ import (
 )
 
 // execGrep runs the "grep" command.
-func execGrep(cfg CommandConfig, args []string) error {
+func execGrep(ctx context.Context, cfg CommandConfig, args []string) error {
 	fs := flag.NewFlagSet("grep", flag.ExitOnError)
 	fs.Parse(args)
 
@@ -37,8 +38,12 @@ func execGrep(cfg CommandConfig, args []string) error {
 
 	passwords := store.GetPasswordsList()
 
-	for _, password := range passwords {
-		decryptedPassword, _ := store.GetPassword(password)
+	for i, password := range passwords {
+		decryptedPassword, _ := store.GetPasswordContext(ctx, password)
+		if err := ctx.Err(); err != nil {
+			fmt.Fprintf(cfg.WriterError(), "grep interrupted after searching %d of %d passwords.\n", i, len(passwords))
+			return err
+		}
 		lines := strings.Split(decryptedPassword, "\n")
 		output := ""
 		for _, line := range lines {
@@ -0,0 +1,47 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package cli_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aviau/gopass/internal/cli"
+	"github.com/aviau/gopass/internal/cli/clitest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGrepInterrupted(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	if err := cliTest.PasswordStore().InsertPassword("test.com", "hello"); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := cliTest.Run([]string{"grep", "hello"}, clitest.WithContext(ctx))
+
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, cli.ExitInterrupted, cli.ExitCode(err))
+	assert.Equal(t, "", result.Stdout.String())
+	assert.Equal(t, "grep interrupted after searching 0 of 1 passwords.\n", result.Stderr.String())
+}
@@ -28,6 +28,7 @@ func execHelp(cfg CommandConfig) error {
       ls                    List passwords.
       find                  List passwords that match a string.
       show                  Show an encryped password.
+      otp                   Generate a one-time password.
       grep                  Search for a string in all passwords.
       insert                Insert a new password.
       edit                  Edit an existing password.
@@ -36,6 +37,8 @@ func execHelp(cfg CommandConfig) error {
       mv                    Move a password.
       cp                    Copy a password.
       git                   Execute a git command.
+      recipients            List the keys that passwords are encrypted to.
+      fsck                  Check the integrity of the password store.
       help                  Show this text.
       version               Show version information.
 `)
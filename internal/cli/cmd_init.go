@@ -18,18 +18,20 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"io/ioutil"
-	"path/filepath"
+	"path"
 	"strings"
 
-	"github.com/aviau/gopass/pkg/store"
+	gopass_store "github.com/aviau/gopass/pkg/store"
 )
 
 // execInit runs the "init" command.
-func execInit(cfg CommandConfig, args []string) error {
-	var path, p string
+func execInit(ctx context.Context, cfg CommandConfig, args []string) error {
+	var subfolder, p string
 	var help, h bool
 
 	fs := flag.NewFlagSet("init", flag.ContinueOnError)
@@ -38,7 +40,7 @@ func execInit(cfg CommandConfig, args []string) error {
 	fs.BoolVar(&help, "help", false, "")
 	fs.BoolVar(&h, "h", false, "")
 
-	fs.StringVar(&path, "path", cfg.PasswordStoreDir(), "")
+	fs.StringVar(&subfolder, "path", "", "")
 	fs.StringVar(&p, "p", "", "")
 
 	fs.Usage = func() {
@@ -55,12 +57,7 @@ func execInit(cfg CommandConfig, args []string) error {
 	}
 
 	if p != "" {
-		path = p
-	}
-
-	path, err := filepath.Abs(path)
-	if err != nil {
-		return err
+		subfolder = p
 	}
 
 	if fs.NArg() < 1 {
@@ -70,37 +67,85 @@ func execInit(cfg CommandConfig, args []string) error {
 
 	gpgIDs := fs.Args()
 
-	store := store.NewPasswordStore(path)
+	store := cfg.PasswordStore()
 
-	// There is no existing store, create one.
-	if len(store.GPGIDs) == 0 {
-		if err := store.Init(gpgIDs); err != nil {
+	if subfolder == "" && len(store.GPGIDs) == 0 {
+		// There is no existing store, create one.
+		if err := store.InitContext(ctx, gpgIDs); err != nil {
 			return err
 		}
-		fmt.Fprintf(cfg.WriterOutput(), "Successfully created Password Store at \"%s\".\n", path)
+		fmt.Fprintf(cfg.WriterOutput(), "Successfully created Password Store at \"%s\".\n", store.Path)
+		return nil
+	}
+
+	// Set the GPG ids and reencrypt the passwords that use them. The store
+	// is left untouched if anything fails.
+	reporter := &reencryptReporter{cfg: cfg, status: "reencrypted to " + strings.Join(gpgIDs, ", ")}
+	progress := reporter.progress()
+
+	var err error
+	if subfolder != "" {
+		subfolder = path.Clean(subfolder)
+		err = store.SetDirectoryGPGIDsContext(ctx, subfolder, gpgIDs, progress)
 	} else {
-		// The store already exists, reencrypt it.
-
-		// First, set the GPG ids...
-		store.SetGPGIDs(gpgIDs)
-
-		// Now, reencrypt every password
-		passwords := store.GetPasswordsList()
-		for _, password := range passwords {
-			fmt.Printf("%s: reencrypting to %s\n", password, strings.Join(gpgIDs, ", "))
-			if err := store.ReencryptPassword(password); err != nil {
-				return err
-			}
-		}
+		err = store.SetGPGIDsContext(ctx, gpgIDs, progress)
+	}
 
-		// Commit
-		if err := store.AddAndCommit(
-			"Reencrypt password store using new GPG id "+strings.Join(gpgIDs, ", "),
-			"*",
-		); err != nil {
-			return err
-		}
+	if err != nil {
+		return reporter.failed(ctx, "init", err)
+	}
+
+	if subfolder != "" {
+		fmt.Fprintf(cfg.WriterOutput(), "Password store subfolder \"%s\" now uses GPG id %s.\n", subfolder, strings.Join(gpgIDs, ", "))
+	} else {
+		fmt.Fprintf(cfg.WriterOutput(), "Password store now uses GPG id %s.\n", strings.Join(gpgIDs, ", "))
 	}
+	reporter.succeeded()
 
 	return nil
 }
+
+// reencryptReporter prints the progress and the outcome of commands that
+// reencrypt passwords.
+type reencryptReporter struct {
+	cfg         CommandConfig
+	status      string // Printed after each reencrypted password
+	reencrypted int
+}
+
+// progress returns the option that prints each reencrypted password.
+func (reporter *reencryptReporter) progress() gopass_store.ReencryptOption {
+	return gopass_store.WithProgress(func(progress gopass_store.ReencryptProgress) {
+		if progress.Err != nil {
+			fmt.Fprintf(reporter.cfg.WriterError(), "[%d/%d] %s: %s\n", progress.Done, progress.Total, progress.Password, progress.Err)
+			return
+		}
+		reporter.reencrypted++
+		fmt.Fprintf(reporter.cfg.WriterOutput(), "[%d/%d] %s: %s\n", progress.Done, progress.Total, progress.Password, reporter.status)
+	})
+}
+
+// failed explains that command failed with err and left the store unchanged.
+// It returns err.
+func (reporter *reencryptReporter) failed(ctx context.Context, command string, err error) error {
+	var reencryptErr *gopass_store.ReencryptError
+	if errors.As(err, &reencryptErr) {
+		fmt.Fprintf(
+			reporter.cfg.WriterError(),
+			"%d passwords were reencrypted, %d failed. The password store was left unchanged.\n",
+			reporter.reencrypted,
+			len(reencryptErr.Summary.Failed),
+		)
+	} else if ctx.Err() != nil {
+		fmt.Fprintf(reporter.cfg.WriterError(), "%s interrupted, the password store was left unchanged.\n", command)
+	}
+
+	return err
+}
+
+// succeeded prints how many passwords were reencrypted, if any.
+func (reporter *reencryptReporter) succeeded() {
+	if reporter.reencrypted > 0 {
+		fmt.Fprintf(reporter.cfg.WriterOutput(), "%d passwords were reencrypted.\n", reporter.reencrypted)
+	}
+}
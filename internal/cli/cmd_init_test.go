@@ -18,10 +18,15 @@
 package cli_test
 
 import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/aviau/gopass/internal/cli/clitest"
+	"github.com/aviau/gopass/pkg/store"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -46,3 +51,81 @@ func TestInitDashH(t *testing.T) {
 	assert.Equal(t, "", result.Stderr.String())
 	assert.True(t, strings.Contains(result.Stdout.String(), "Usage: gopass init [--path=subfolder,-p subfolder] gpg-id..."))
 }
+
+func TestInitSubfolder(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	if err := os.Mkdir(filepath.Join(cliTest.PasswordStore().Path, "ops"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cliTest.PasswordStore().InsertPassword("ops/test.com", "ops password"); err != nil {
+		t.Fatal(err)
+	}
+
+	rootGPGIDs := cliTest.PasswordStore().GPGIDs
+
+	result, err := cliTest.Run([]string{"init", "--path=ops", rootGPGIDs[0]})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "", result.Stderr.String())
+
+	gpgIDContent, err := ioutil.ReadFile(filepath.Join(cliTest.PasswordStore().Path, "ops", ".gpg-id"))
+	assert.Nil(t, err)
+	assert.Equal(t, rootGPGIDs[0]+"\n", string(gpgIDContent))
+
+	assert.Equal(t, rootGPGIDs, cliTest.PasswordStore().GPGIDs, "the root GPG ids should not change")
+
+	assert.True(t, strings.Contains(result.Stdout.String(), "ops/test.com: reencrypted"))
+
+	decryptedPassword, err := cliTest.PasswordStore().GetPassword("ops/test.com")
+	assert.Nil(t, err)
+	assert.Equal(t, "ops password", decryptedPassword)
+}
+
+func TestInitSigningKey(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	if err := os.Mkdir(filepath.Join(cliTest.PasswordStore().Path, "ops"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	rootGPGIDs := cliTest.PasswordStore().GPGIDs
+	cliTest.PasswordStore().SigningKeys = []string{"C6E1F43ADED43F7E5278C2B2CED3B67C8F1F6CA9"}
+
+	_, err := cliTest.Run([]string{"init", "--path=ops", rootGPGIDs[0]})
+	assert.Nil(t, err)
+
+	_, err = os.Stat(filepath.Join(cliTest.PasswordStore().Path, "ops", ".gpg-id.sig"))
+	assert.Nil(t, err)
+
+	err = cliTest.PasswordStore().InsertPassword("ops/test.com", "password")
+	assert.Nil(t, err)
+
+	gpgIDPath := filepath.Join(cliTest.PasswordStore().Path, "ops", ".gpg-id")
+	if err := ioutil.WriteFile(gpgIDPath, []byte(rootGPGIDs[0]+"\nattacker@example.com\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err = cliTest.PasswordStore().InsertPassword("ops/test.com", "password")
+	assert.True(t, errors.Is(err, store.ErrBadSignature))
+
+	_, err = cliTest.Run([]string{"init", "--path=ops", rootGPGIDs[0]})
+	assert.Nil(t, err, "init should sign the .gpg-id again")
+}
+
+func TestInitSigningKeyWithoutSecretKey(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	rootGPGIDs := cliTest.PasswordStore().GPGIDs
+	cliTest.PasswordStore().SigningKeys = []string{"0000000000000000000000000000000000000000"}
+
+	_, err := cliTest.Run([]string{"init", "--path=ops", rootGPGIDs[0]})
+	assert.NotNil(t, err)
+
+	_, err = os.Stat(filepath.Join(cliTest.PasswordStore().Path, "ops", ".gpg-id"))
+	assert.True(t, os.IsNotExist(err), "the .gpg-id file should not have been written")
+}
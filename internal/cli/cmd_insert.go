@@ -18,6 +18,7 @@
 package cli
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"io/ioutil"
@@ -29,7 +30,7 @@ import (
 )
 
 // execInsert runs the "insert" command.
-func execInsert(cfg CommandConfig, args []string) error {
+func execInsert(ctx context.Context, cfg CommandConfig, args []string) error {
 	var multiline, m bool
 	var force, f bool
 	var help, h bool
@@ -102,7 +103,7 @@ func execInsert(cfg CommandConfig, args []string) error {
 		}
 	}
 
-	if err := store.InsertPassword(pwname, password); err != nil {
+	if err := store.InsertPasswordContext(ctx, pwname, password); err != nil {
 		return err
 	}
 
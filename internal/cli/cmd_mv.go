@@ -18,22 +18,28 @@
 package cli
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"io/ioutil"
 	"path/filepath"
 	"strings"
+
+	gopass_store "github.com/aviau/gopass/pkg/store"
 )
 
 // execMv runs the "mv" comand.
-func execMv(cfg CommandConfig, args []string) error {
+func execMv(ctx context.Context, cfg CommandConfig, args []string) error {
 	var force, f bool
+	var noReencrypt bool
 	var help, h bool
 
 	fs := flag.NewFlagSet("mv", flag.ContinueOnError)
 	fs.SetOutput(ioutil.Discard)
 
-	fs.Usage = func() { fmt.Fprintln(cfg.WriterOutput(), "Usage: gopass mv old-path new-path") }
+	fs.Usage = func() {
+		fmt.Fprintln(cfg.WriterOutput(), "Usage: gopass mv [--force,-f] [--no-reencrypt] old-path new-path")
+	}
 
 	fs.BoolVar(&help, "help", false, "")
 	fs.BoolVar(&h, "h", false, "")
@@ -41,6 +47,8 @@ func execMv(cfg CommandConfig, args []string) error {
 	fs.BoolVar(&force, "force", false, "")
 	fs.BoolVar(&f, "f", false, "")
 
+	fs.BoolVar(&noReencrypt, "no-reencrypt", false, "")
+
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
@@ -53,6 +61,7 @@ func execMv(cfg CommandConfig, args []string) error {
 	force = force || f
 
 	store := cfg.PasswordStore()
+	reencrypt := gopass_store.WithReencrypt(!noReencrypt)
 
 	source := fs.Arg(0)
 	dest := fs.Arg(1)
@@ -71,11 +80,11 @@ func execMv(cfg CommandConfig, args []string) error {
 
 		if destAlreadyExists, _ := store.ContainsPassword(dest); destAlreadyExists {
 			if !force {
-				return fmt.Errorf("destination \"%s\" already exists. Use -f to override", dest)
+				return newCommandError(gopass_store.ErrAlreadyExists, "destination \"%s\" already exists. Use -f to override", dest)
 			}
 		}
 
-		if err := store.MovePassword(source, dest); err != nil {
+		if err := store.MovePasswordContext(ctx, source, dest, reencrypt); err != nil {
 			return err
 		}
 
@@ -84,12 +93,12 @@ func execMv(cfg CommandConfig, args []string) error {
 	}
 
 	if sourceIsDirectory, _ := store.ContainsDirectory(source); sourceIsDirectory {
-		if err := store.MoveDirectory(source, dest); err != nil {
+		if err := store.MoveDirectoryContext(ctx, source, dest, reencrypt); err != nil {
 			return err
 		}
 		fmt.Fprintf(cfg.WriterOutput(), "Moved directory from \"%s\" to \"%s\".\n", source, dest)
 		return nil
 	}
 
-	return fmt.Errorf("could not find source \"%s\" to copy", source)
+	return notFoundError(store, source, "could not find source \"%s\" to move", source)
 }
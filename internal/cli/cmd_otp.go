@@ -0,0 +1,457 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+
+	gopass_qrcode "github.com/aviau/gopass/internal/qrcode"
+	gopass_terminal "github.com/aviau/gopass/internal/terminal"
+	gopass_otp "github.com/aviau/gopass/pkg/otp"
+	"github.com/aviau/gopass/pkg/secret"
+	gopass_store "github.com/aviau/gopass/pkg/store"
+)
+
+// otpWaitThreshold is how long a code must remain valid for --wait not to
+// wait for the next one.
+const otpWaitThreshold = 5 * time.Second
+
+// execOtp runs the "otp" command.
+func execOtp(ctx context.Context, cfg CommandConfig, args []string) error {
+	if len(args) > 0 && args[0] == "insert" {
+		return execOtpInsert(ctx, cfg, args[1:])
+	}
+	if len(args) > 0 && args[0] == "import" {
+		return execOtpImport(ctx, cfg, args[1:])
+	}
+
+	var clip, c bool
+	var wait, w bool
+	var help, h bool
+	var qrcode bool
+	var qrcodePNG string
+
+	fs := flag.NewFlagSet("otp", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+
+	fs.Usage = func() {
+		fmt.Fprintln(cfg.WriterOutput(), `Usage: gopass otp [--clip,-c] [--wait,-w] [--qrcode|--qrcode-png=file] pass-name
+       gopass otp insert [--force,-f] pass-name
+       gopass otp import [--path=subfolder,-p subfolder] [--force,-f]`)
+	}
+
+	fs.BoolVar(&help, "help", false, "")
+	fs.BoolVar(&h, "h", false, "")
+
+	fs.BoolVar(&clip, "clip", false, "")
+	fs.BoolVar(&c, "c", false, "")
+
+	fs.BoolVar(&wait, "wait", false, "")
+	fs.BoolVar(&w, "w", false, "")
+
+	fs.BoolVar(&qrcode, "qrcode", false, "")
+	fs.StringVar(&qrcodePNG, "qrcode-png", "", "")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if help || h {
+		fs.Usage()
+		return nil
+	}
+
+	clip = clip || c
+
+	wait = wait || w
+
+	if (qrcode || qrcodePNG != "") && (clip || wait) {
+		return errors.New("--qrcode and --qrcode-png can't be used with --clip or --wait")
+	}
+
+	password := fs.Arg(0)
+
+	if password == "" {
+		return errors.New("missing password name")
+	}
+
+	store := cfg.PasswordStore()
+
+	content, err := store.GetRawPasswordContext(ctx, password)
+	if err != nil {
+		return err
+	}
+
+	entry := secret.Parse(content)
+
+	key, err := gopass_otp.FromSecret(entry)
+	if err != nil {
+		return err
+	}
+
+	// The QR codes hold the key itself, to add it to an authenticator app.
+	if qrcodePNG != "" {
+		if err := writeQRCodePNG(key.URL(), qrcodePNG); err != nil {
+			return err
+		}
+		fmt.Fprintf(cfg.WriterOutput(), "Wrote the QR code of the otp key of \"%s\" to %s.\n", password, qrcodePNG)
+	}
+	if qrcode {
+		return gopass_qrcode.Terminal(cfg.WriterOutput(), key.URL())
+	} else if qrcodePNG != "" {
+		return nil
+	}
+
+	now := cfg.Now()
+	remaining := key.Remaining(now)
+
+	if key.Type == gopass_otp.TypeHOTP && wait {
+		return errors.New("--wait can't be used with hotp keys")
+	}
+
+	if wait && remaining < otpWaitThreshold {
+		fmt.Fprintf(cfg.WriterOutput(), "The code expires in %d seconds, waiting for the next one.\n", int(remaining.Seconds()))
+		timer := time.NewTimer(remaining)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+		now = now.Add(remaining)
+		remaining = key.Remaining(now)
+	}
+
+	code, err := key.Code(now)
+	if err != nil {
+		return err
+	}
+
+	// The counter of hotp keys is saved before the code is shown, so that
+	// the same code is never used twice.
+	if key.Type == gopass_otp.TypeHOTP {
+		key.SetCounter(key.Counter + 1)
+		key.SaveTo(entry)
+
+		batch := store.NewBatch()
+		batch.InsertPassword(password, entry.String())
+		if err := batch.ApplyContext(ctx, fmt.Sprintf("Increment the otp counter of \"%s\" to %d", password, key.Counter)); err != nil {
+			return fmt.Errorf("could not save the otp counter: %w", err)
+		}
+	}
+
+	if clip {
+		if err := copyToClipboard(cfg, code, fmt.Sprintf("the otp code of \"%s\"", password)); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintln(cfg.WriterOutput(), code)
+	}
+	if key.Type == gopass_otp.TypeHOTP {
+		fmt.Fprintf(cfg.WriterOutput(), "The otp counter is now %d.\n", key.Counter)
+	} else {
+		fmt.Fprintf(cfg.WriterOutput(), "The code expires in %d seconds.\n", int(remaining.Seconds()))
+	}
+
+	return nil
+}
+
+// execOtpInsert runs the "otp insert" command.
+func execOtpInsert(ctx context.Context, cfg CommandConfig, args []string) error {
+	var force, f bool
+	var help, h bool
+
+	fs := flag.NewFlagSet("otp insert", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+
+	fs.Usage = func() {
+		fmt.Fprintln(cfg.WriterOutput(), "Usage: gopass otp insert [--force,-f] pass-name")
+	}
+
+	fs.BoolVar(&help, "help", false, "")
+	fs.BoolVar(&h, "h", false, "")
+
+	fs.BoolVar(&force, "force", false, "")
+	fs.BoolVar(&f, "f", false, "")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if help || h {
+		fs.Usage()
+		return nil
+	}
+
+	force = force || f
+
+	password := fs.Arg(0)
+
+	if password == "" {
+		return errors.New("missing password name")
+	}
+
+	value, err := readOtpKey(cfg)
+	if err != nil {
+		return fmt.Errorf("could not read the otp key: %w", err)
+	}
+	if value == "" {
+		return errors.New("missing otp key")
+	}
+
+	// Bare secrets are labelled with the name of the password, for
+	// authenticator apps.
+	var key *gopass_otp.Key
+	if strings.HasPrefix(value, gopass_otp.Scheme+"://") {
+		key, err = gopass_otp.ParseURL(value)
+	} else {
+		key, err = gopass_otp.NewTOTP(password, value)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Make sure that the key works before saving it.
+	code, err := key.Code(cfg.Now())
+	if err != nil {
+		return err
+	}
+
+	store := cfg.PasswordStore()
+
+	entry, hasKey, err := loadOtpEntry(ctx, store, password)
+	if err != nil {
+		return err
+	}
+
+	action := "Add"
+	if hasKey {
+		if !force && !gopass_terminal.AskYesNo(cfg.WriterOutput(), fmt.Sprintf("Password \"%s\" already has an otp key. Would you like to overwrite it? [y/n] ", password)) {
+			return nil
+		}
+		action = "Replace"
+	}
+
+	batch := store.NewBatch()
+	batch.InsertPassword(password, saveOtpKey(entry, key))
+	if err := batch.ApplyContext(ctx, fmt.Sprintf("%s the otp key of \"%s\"", action, password)); err != nil {
+		return err
+	}
+
+	if action == "Replace" {
+		fmt.Fprintf(cfg.WriterOutput(), "Replaced the otp key of \"%s\".\n", password)
+	} else {
+		fmt.Fprintf(cfg.WriterOutput(), "Added an otp key to \"%s\".\n", password)
+	}
+	// The code of hotp keys is not shown since the counter is not
+	// incremented.
+	if key.Type == gopass_otp.TypeTOTP {
+		fmt.Fprintf(cfg.WriterOutput(), "Its current code is %s.\n", code)
+	}
+
+	return nil
+}
+
+// execOtpImport runs the "otp import" command.
+func execOtpImport(ctx context.Context, cfg CommandConfig, args []string) error {
+	var subfolder, p string
+	var force, f bool
+	var help, h bool
+
+	fs := flag.NewFlagSet("otp import", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+
+	fs.Usage = func() {
+		fmt.Fprintln(cfg.WriterOutput(), "Usage: gopass otp import [--path=subfolder,-p subfolder] [--force,-f]")
+	}
+
+	fs.BoolVar(&help, "help", false, "")
+	fs.BoolVar(&h, "h", false, "")
+
+	fs.StringVar(&subfolder, "path", "", "")
+	fs.StringVar(&p, "p", "", "")
+
+	fs.BoolVar(&force, "force", false, "")
+	fs.BoolVar(&f, "f", false, "")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if help || h {
+		fs.Usage()
+		return nil
+	}
+
+	if p != "" {
+		subfolder = p
+	}
+
+	force = force || f
+
+	// Read the otpauth-migration:// URIs, one per line, along with any
+	// otpauth:// URI.
+	var keys []*gopass_otp.Key
+	scanner := bufio.NewScanner(cfg.ReaderInput())
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, gopass_otp.MigrationScheme+"://"):
+			migrationKeys, err := gopass_otp.ParseMigrationURL(line)
+			if err != nil {
+				return err
+			}
+			keys = append(keys, migrationKeys...)
+		default:
+			key, err := gopass_otp.ParseURL(line)
+			if err != nil {
+				return err
+			}
+			keys = append(keys, key)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("could not read the otp keys: %w", err)
+	}
+
+	if len(keys) == 0 {
+		return errors.New("missing otpauth-migration:// URI")
+	}
+
+	store := cfg.PasswordStore()
+
+	batch := store.NewBatch()
+	imported := map[string]bool{}
+	skipped := 0
+
+	for _, key := range keys {
+		password := otpImportName(subfolder, key)
+
+		if imported[password] {
+			fmt.Fprintf(cfg.WriterError(), "Skipped \"%s\": another imported key has the same name.\n", password)
+			skipped++
+			continue
+		}
+
+		entry, hasKey, err := loadOtpEntry(ctx, store, password)
+		if err != nil {
+			return err
+		}
+		if hasKey && !force {
+			fmt.Fprintf(cfg.WriterError(), "Skipped \"%s\": it already has an otp key, use --force to replace it.\n", password)
+			skipped++
+			continue
+		}
+
+		batch.InsertPassword(password, saveOtpKey(entry, key))
+		imported[password] = true
+
+		if hasKey {
+			fmt.Fprintf(cfg.WriterOutput(), "Replaced the otp key of \"%s\".\n", password)
+		} else {
+			fmt.Fprintf(cfg.WriterOutput(), "Added an otp key to \"%s\".\n", password)
+		}
+	}
+
+	if batch.Len() > 0 {
+		if err := batch.ApplyContext(ctx, fmt.Sprintf("Import %d otp keys", batch.Len())); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(cfg.WriterOutput(), "Imported %d otp keys, %d skipped.\n", batch.Len(), skipped)
+
+	return nil
+}
+
+// otpImportName returns the name of the password of an imported key, which
+// is "issuer/account" or "account". Names can't contain parts starting with a
+// dot, so leading dots are removed.
+func otpImportName(subfolder string, key *gopass_otp.Key) string {
+	var parts []string
+	for _, part := range []string{key.Issuer, key.Account} {
+		part = strings.TrimSpace(strings.ReplaceAll(part, "/", "-"))
+		part = strings.TrimSpace(strings.TrimLeft(part, "."))
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "otp")
+	}
+	return path.Join(append([]string{subfolder}, parts...)...)
+}
+
+// loadOtpEntry returns the parsed content of a password, or nil if it does
+// not exist, and whether or not it has an otp key.
+func loadOtpEntry(ctx context.Context, store *gopass_store.PasswordStore, password string) (*secret.Secret, bool, error) {
+	if containsPassword, _ := store.ContainsPassword(password); !containsPassword {
+		return nil, false, nil
+	}
+
+	content, err := store.GetRawPasswordContext(ctx, password)
+	if err != nil {
+		return nil, false, err
+	}
+	entry := secret.Parse(content)
+
+	_, err = gopass_otp.FromSecret(entry)
+	return entry, !errors.Is(err, gopass_otp.ErrNotFound), nil
+}
+
+// saveOtpKey returns the content of a password loaded by loadOtpEntry with
+// the otp key saved into it. As with pass-otp, new passwords only hold the
+// key.
+func saveOtpKey(entry *secret.Secret, key *gopass_otp.Key) string {
+	if entry == nil {
+		return key.URL()
+	}
+	key.SaveTo(entry)
+	return entry.String()
+}
+
+// readOtpKey reads an otp key from the terminal without echoing it, or from
+// the first line of the standard input.
+func readOtpKey(cfg CommandConfig) (string, error) {
+	if file, ok := cfg.ReaderInput().(*os.File); ok && term.IsTerminal(int(file.Fd())) {
+		fmt.Fprintln(cfg.WriterOutput(), "Enter the otpauth:// URI or base32 secret:")
+		value, err := term.ReadPassword(int(file.Fd()))
+		fmt.Fprintln(cfg.WriterOutput())
+		return strings.TrimSpace(string(value)), err
+	}
+
+	value, err := bufio.NewReader(cfg.ReaderInput()).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	return strings.TrimSpace(value), nil
+}
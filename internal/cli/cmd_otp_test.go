@@ -0,0 +1,316 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package cli_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aviau/gopass/internal/cli/clitest"
+	"github.com/stretchr/testify/assert"
+)
+
+// otpURI is the RFC 6238 SHA256 test key, with 8 digits.
+const otpURI = "otpauth://totp/Example:alice@example.com?secret=GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZA&digits=8&algorithm=SHA256&issuer=Example"
+
+const otpPassword = "pass123\n" + otpURI + "\n"
+
+func TestOtpDashDashHelp(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	result, err := cliTest.Run([]string{"otp", "--help"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "", result.Stderr.String())
+	assert.True(t, strings.Contains(result.Stdout.String(), "Usage: gopass otp"))
+}
+
+func TestOtp(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	if err := cliTest.PasswordStore().InsertPassword("test.com", otpPassword); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := cliTest.Run(
+		[]string{"otp", "test.com"},
+		clitest.WithFixedTime(time.Unix(40, 0)),
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "", result.Stderr.String())
+	assert.Equal(t, "46119246\nThe code expires in 20 seconds.\n", result.Stdout.String())
+}
+
+func TestOtpClip(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	if err := cliTest.PasswordStore().InsertPassword("test.com", otpPassword); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := cliTest.Run(
+		[]string{"otp", "-c", "test.com"},
+		clitest.WithFixedTime(time.Unix(40, 0)),
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Copied the otp code of \"test.com\" to clipboard.\nThe code expires in 20 seconds.\n", result.Stdout.String())
+	assert.Equal(t, "46119246", cliTest.Clipboard())
+}
+
+func TestOtpWait(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	if err := cliTest.PasswordStore().InsertPassword("test.com", otpPassword); err != nil {
+		t.Fatal(err)
+	}
+
+	// The code of 0:59 expires in a second, so the one of 1:00 is shown.
+	result, err := cliTest.Run(
+		[]string{"otp", "--wait", "test.com"},
+		clitest.WithFixedTime(time.Unix(59, 0)),
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		"The code expires in 1 seconds, waiting for the next one.\n30882438\nThe code expires in 30 seconds.\n",
+		result.Stdout.String(),
+	)
+}
+
+func TestOtpNotFound(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	if err := cliTest.PasswordStore().InsertPassword("test.com", "pass123\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := cliTest.Run([]string{"otp", "test.com"})
+
+	assert.EqualError(t, err, "could not find an otp key in the password")
+}
+
+func TestOtpQRCodePNG(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	if err := cliTest.PasswordStore().InsertPassword("test.com", otpPassword); err != nil {
+		t.Fatal(err)
+	}
+
+	pngPath := filepath.Join(t.TempDir(), "qrcode.png")
+	result, err := cliTest.Run([]string{"otp", "--qrcode-png", pngPath, "test.com"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Wrote the QR code of the otp key of \"test.com\" to "+pngPath+".\n", result.Stdout.String())
+
+	_, err = os.Stat(pngPath)
+	assert.Nil(t, err)
+}
+
+func TestOtpHOTP(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	// The RFC 4226 test key.
+	// The password and the end of the entry are kept as they are.
+	password := "  pass123\n2fa: otpauth://hotp/test?secret=GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ&counter=0\nnotes\n"
+	if err := cliTest.PasswordStore().InsertPassword("test.com", password); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{"755224\nThe otp counter is now 1.\n", "287082\nThe otp counter is now 2.\n"} {
+		result, err := cliTest.Run([]string{"otp", "test.com"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "", result.Stderr.String())
+		assert.Equal(t, expected, result.Stdout.String())
+	}
+
+	content, err := cliTest.PasswordStore().GetRawPassword("test.com")
+	assert.Nil(t, err)
+	assert.Equal(t, "  pass123\n2fa: otpauth://hotp/test?secret=GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ&counter=2\nnotes\n", content)
+
+	_, err = cliTest.Run([]string{"otp", "--wait", "test.com"})
+	assert.EqualError(t, err, "--wait can't be used with hotp keys")
+
+	_, err = cliTest.Run([]string{"show", "--2fa", "test.com"})
+	assert.EqualError(t, err, "--2fa can't be used with hotp keys, use \"gopass otp\" instead")
+}
+
+func TestOtpInsert(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	if err := cliTest.PasswordStore().InsertPassword("test.com", "pass123 \nusername: alice\n\nnotes\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := cliTest.Run(
+		[]string{"otp", "insert", "test.com"},
+		clitest.WithInput(strings.NewReader("jbsw y3dp ehpk 3pxp\n")),
+		clitest.WithFixedTime(time.Date(2020, 1, 2, 15, 0, 0, 0, time.UTC)),
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "", result.Stderr.String())
+	assert.Equal(t, "Added an otp key to \"test.com\".\nIts current code is 891690.\n", result.Stdout.String())
+
+	content, err := cliTest.PasswordStore().GetRawPassword("test.com")
+	assert.Nil(t, err)
+	assert.Equal(t, "pass123 \nusername: alice\n2fa: otpauth://totp/test.com?secret=JBSWY3DPEHPK3PXP\n\nnotes\n", content)
+
+	// Replacing the key keeps it where it was.
+	result, err = cliTest.Run(
+		[]string{"otp", "insert", "-f", "test.com"},
+		clitest.WithInput(strings.NewReader(otpURI)),
+		clitest.WithFixedTime(time.Unix(40, 0)),
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Replaced the otp key of \"test.com\".\nIts current code is 46119246.\n", result.Stdout.String())
+
+	content, err = cliTest.PasswordStore().GetRawPassword("test.com")
+	assert.Nil(t, err)
+	assert.Equal(t, "pass123 \nusername: alice\n2fa: "+otpURI+"\n\nnotes\n", content)
+}
+
+func TestOtpInsertNewPassword(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	result, err := cliTest.Run(
+		[]string{"otp", "insert", "vpn"},
+		clitest.WithInput(strings.NewReader("otpauth://hotp/vpn?secret=JBSWY3DPEHPK3PXP&counter=3\n")),
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Added an otp key to \"vpn\".\n", result.Stdout.String())
+
+	content, err := cliTest.PasswordStore().GetPassword("vpn")
+	assert.Nil(t, err)
+	assert.Equal(t, "otpauth://hotp/vpn?secret=JBSWY3DPEHPK3PXP&counter=3", content)
+}
+
+func TestOtpInsertInvalid(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	for input, message := range map[string]string{
+		"":                    "missing otp key",
+		"not base32!":         "the otp secret is not valid base32",
+		"otpauth://totp/test": "the otp key has no secret",
+	} {
+		_, err := cliTest.Run([]string{"otp", "insert", "test.com"}, clitest.WithInput(strings.NewReader(input)))
+
+		assert.EqualError(t, err, message)
+	}
+
+	contains, _ := cliTest.PasswordStore().ContainsPassword("test.com")
+	assert.False(t, contains)
+}
+
+// otpMigrationURI holds the TOTP key of Example:alice@example.com, the HOTP
+// key of vpn and the TOTP key of Example:bob.
+const otpMigrationURI = "otpauth-migration://offline?data=CjYKCkhlbGxvId6tvu8SGUV4YW1wbGU6YWxpY2VAZXhhbXBsZS5jb20aB0V4YW1wbGUgASgBMAIKHwoUMTIzNDU2Nzg5MDEyMzQ1Njc4OTASA3ZwbjABOAMKJgoUMTIzNDU2Nzg5MDEyMzQ1Njc4OTASA2JvYhoHRXhhbXBsZTACEAEYAQ%3D%3D"
+
+func TestOtpImport(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	if err := cliTest.PasswordStore().InsertPassword("vpn", "pass123\nusername: alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cliTest.PasswordStore().InsertPassword("Example/bob", "pass456\n2fa: JBSWY3DPEHPK3PXP"); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := cliTest.Run(
+		[]string{"otp", "import"},
+		clitest.WithInput(strings.NewReader(otpMigrationURI+"\n")),
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Skipped \"Example/bob\": it already has an otp key, use --force to replace it.\n", result.Stderr.String())
+	assert.Equal(
+		t,
+		"Added an otp key to \"Example/alice@example.com\".\nAdded an otp key to \"vpn\".\nImported 2 otp keys, 1 skipped.\n",
+		result.Stdout.String(),
+	)
+
+	for password, expected := range map[string]string{
+		"Example/alice@example.com": "otpauth://totp/Example:alice@example.com?algorithm=SHA1&digits=6&issuer=Example&secret=JBSWY3DPEHPK3PXP",
+		"vpn":                       "pass123\nusername: alice\n2fa: otpauth://hotp/vpn?counter=3&secret=GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ",
+		"Example/bob":               "pass456\n2fa: JBSWY3DPEHPK3PXP",
+	} {
+		content, err := cliTest.PasswordStore().GetPassword(password)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, content)
+	}
+
+	result, err = cliTest.Run(
+		[]string{"otp", "import", "--force", "-p", "phone"},
+		clitest.WithInput(strings.NewReader(otpMigrationURI+"\n")),
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "", result.Stderr.String())
+	assert.True(t, strings.HasSuffix(result.Stdout.String(), "Imported 3 otp keys, 0 skipped.\n"))
+
+	contains, _ := cliTest.PasswordStore().ContainsPassword("phone/Example/bob")
+	assert.True(t, contains)
+}
+
+func TestOtpImportDotNames(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	// The issuer is ".hidden" and the account is "..".
+	result, err := cliTest.Run(
+		[]string{"otp", "import"},
+		clitest.WithInput(strings.NewReader("otpauth-migration://offline?data=Ch8KCkhlbGxvId6tvu8SAi4uGgcuaGlkZGVuIAEoATAC\n")),
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "", result.Stderr.String())
+	assert.Equal(t, "Added an otp key to \"hidden\".\nImported 1 otp keys, 0 skipped.\n", result.Stdout.String())
+
+	contains, _ := cliTest.PasswordStore().ContainsPassword("hidden")
+	assert.True(t, contains)
+}
+
+func TestOtpImportInvalid(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	_, err := cliTest.Run([]string{"otp", "import"}, clitest.WithInput(strings.NewReader("")))
+	assert.EqualError(t, err, "missing otpauth-migration:// URI")
+
+	_, err = cliTest.Run([]string{"otp", "import"}, clitest.WithInput(strings.NewReader("otpauth-migration://offline?data=CgE")))
+	assert.EqualError(t, err, "invalid otpauth-migration payload")
+}
@@ -0,0 +1,142 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/mgutz/ansi"
+)
+
+// execRecipients runs the "recipients" command.
+func execRecipients(ctx context.Context, cfg CommandConfig, args []string) error {
+	if len(args) > 0 && (args[0] == "add" || args[0] == "remove") {
+		return execRecipientsEdit(ctx, cfg, args[0], args[1:])
+	}
+
+	var help, h bool
+
+	fs := flag.NewFlagSet("recipients", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+
+	fs.Usage = func() {
+		fmt.Fprintln(cfg.WriterOutput(), `Usage: gopass recipients [pass-name|subfolder]
+       gopass recipients add [--path=subfolder,-p subfolder] gpg-id
+       gopass recipients remove [--path=subfolder,-p subfolder] gpg-id`)
+	}
+
+	fs.BoolVar(&help, "help", false, "")
+	fs.BoolVar(&h, "h", false, "")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if help || h {
+		fs.Usage()
+		return nil
+	}
+
+	store := cfg.PasswordStore()
+
+	passwords, err := store.RecipientsContext(ctx, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	for _, password := range passwords {
+		fmt.Fprintln(cfg.WriterOutput(), ansi.Color(password.Password, "cyan+b"))
+		for _, recipient := range password.Recipients {
+			userIDs := strings.Join(recipient.UserIDs, ", ")
+			if userIDs == "" {
+				userIDs = "(unknown key)"
+			}
+			fmt.Fprintf(cfg.WriterOutput(), "  %s %s\n", recipient.KeyID, userIDs)
+		}
+	}
+
+	return nil
+}
+
+// execRecipientsEdit runs the "recipients add" and "recipients remove"
+// commands.
+func execRecipientsEdit(ctx context.Context, cfg CommandConfig, action string, args []string) error {
+	var subfolder, p string
+	var help, h bool
+
+	fs := flag.NewFlagSet("recipients "+action, flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+
+	fs.Usage = func() {
+		fmt.Fprintf(cfg.WriterOutput(), "Usage: gopass recipients %s [--path=subfolder,-p subfolder] gpg-id\n", action)
+	}
+
+	fs.BoolVar(&help, "help", false, "")
+	fs.BoolVar(&h, "h", false, "")
+
+	fs.StringVar(&subfolder, "path", "", "")
+	fs.StringVar(&p, "p", "", "")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if help || h {
+		fs.Usage()
+		return nil
+	}
+
+	if p != "" {
+		subfolder = p
+	}
+
+	gpgID := fs.Arg(0)
+	if gpgID == "" {
+		fs.Usage()
+		return nil
+	}
+
+	store := cfg.PasswordStore()
+
+	reporter := &reencryptReporter{cfg: cfg, status: "reencrypted"}
+	progress := reporter.progress()
+
+	var err error
+	if action == "add" {
+		err = store.AddGPGIDContext(ctx, subfolder, gpgID, progress)
+	} else {
+		err = store.RemoveGPGIDContext(ctx, subfolder, gpgID, progress)
+	}
+
+	if err != nil {
+		return reporter.failed(ctx, "recipients "+action, err)
+	}
+
+	if action == "add" {
+		fmt.Fprintf(cfg.WriterOutput(), "Added GPG id %s.\n", gpgID)
+	} else {
+		fmt.Fprintf(cfg.WriterOutput(), "Removed GPG id %s.\n", gpgID)
+	}
+	reporter.succeeded()
+
+	return nil
+}
@@ -0,0 +1,107 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package cli_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aviau/gopass/internal/cli/clitest"
+	"github.com/aviau/gopass/pkg/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecipientsDashDashHelp(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	result, err := cliTest.Run([]string{"recipients", "--help"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "", result.Stderr.String())
+	assert.True(t, strings.Contains(result.Stdout.String(), "Usage: gopass recipients"))
+}
+
+func TestRecipients(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	if err := cliTest.PasswordStore().InsertPassword("test.com", "password"); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := cliTest.Run([]string{"recipients", "test.com"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "", result.Stderr.String())
+	assert.True(t, strings.Contains(result.Stdout.String(), "test.com"))
+	assert.True(t, strings.Contains(result.Stdout.String(), "  A87E72F491AD6E02 gopass tests\n"))
+}
+
+func TestRecipientsAddRemove(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	if err := os.Mkdir(filepath.Join(cliTest.PasswordStore().Path, "ops"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cliTest.PasswordStore().InsertPassword("ops/test.com", "ops password"); err != nil {
+		t.Fatal(err)
+	}
+
+	rootGPGIDs := cliTest.PasswordStore().GPGIDs
+
+	// The ID of the test key's subkey is another name for the same key.
+	result, err := cliTest.Run([]string{"recipients", "add", "--path=ops", "A87E72F491AD6E02"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "", result.Stderr.String())
+	assert.True(t, strings.Contains(result.Stdout.String(), "ops/test.com: reencrypted"))
+	assert.True(t, strings.Contains(result.Stdout.String(), "Added GPG id A87E72F491AD6E02."))
+
+	gpgIDContent, err := ioutil.ReadFile(filepath.Join(cliTest.PasswordStore().Path, "ops", ".gpg-id"))
+	assert.Nil(t, err)
+	assert.Equal(t, rootGPGIDs[0]+"\nA87E72F491AD6E02\n", string(gpgIDContent))
+
+	result, err = cliTest.Run([]string{"recipients", "remove", "-p", "ops", rootGPGIDs[0]})
+
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(result.Stdout.String(), "Removed GPG id "+rootGPGIDs[0]+"."))
+
+	gpgIDContent, err = ioutil.ReadFile(filepath.Join(cliTest.PasswordStore().Path, "ops", ".gpg-id"))
+	assert.Nil(t, err)
+	assert.Equal(t, "A87E72F491AD6E02\n", string(gpgIDContent))
+
+	decryptedPassword, err := cliTest.PasswordStore().GetPassword("ops/test.com")
+	assert.Nil(t, err)
+	assert.Equal(t, "ops password", decryptedPassword)
+}
+
+func TestRecipientsAddUnknownKey(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	_, err := cliTest.Run([]string{"recipients", "add", "unknown@example.com"})
+
+	assert.True(t, errors.Is(err, store.ErrUnknownKey))
+}
@@ -18,6 +18,7 @@
 package cli
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"io/ioutil"
@@ -26,7 +27,7 @@ import (
 )
 
 // execRm runs the "rm" command.
-func execRm(cfg CommandConfig, args []string) error {
+func execRm(ctx context.Context, cfg CommandConfig, args []string) error {
 	var recursive, r bool
 	var force, f bool
 	var help, h bool
@@ -75,7 +76,7 @@ func execRm(cfg CommandConfig, args []string) error {
 			}
 		}
 
-		if err := store.RemovePassword(pwname); err != nil {
+		if err := store.RemovePasswordContext(ctx, pwname); err != nil {
 			return err
 		}
 
@@ -91,11 +92,11 @@ func execRm(cfg CommandConfig, args []string) error {
 			}
 		}
 
-		if err := store.RemoveDirectory(pwname); err != nil {
+		if err := store.RemoveDirectoryContext(ctx, pwname); err != nil {
 			return err
 		}
 	} else {
-		return fmt.Errorf("could not find password or directory to remove")
+		return notFoundError(store, pwname, "could not find password or directory to remove")
 	}
 
 	fmt.Fprintf(cfg.WriterOutput(), "Removed password/directory at path \"%s\".\n", fs.Arg(0))
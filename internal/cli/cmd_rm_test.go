@@ -23,6 +23,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/aviau/gopass/internal/cli"
 	"github.com/aviau/gopass/internal/cli/clitest"
 	"github.com/stretchr/testify/assert"
 )
@@ -71,3 +72,12 @@ func TestRmUnexistingPassword(t *testing.T) {
 
 	assert.EqualError(t, err, "could not find password or directory to remove")
 }
+
+func TestRmUnexistingPasswordExitCode(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	_, err := cliTest.Run([]string{"rm", "dir"})
+
+	assert.Equal(t, cli.ExitNotFound, cli.ExitCode(err))
+}
@@ -18,6 +18,9 @@
 package cli
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
@@ -26,25 +29,64 @@ import (
 	"strings"
 
 	"github.com/aviau/gopass/internal/clipboard"
-	"github.com/pquerna/otp"
-	"github.com/pquerna/otp/totp"
+	gopass_io "github.com/aviau/gopass/internal/io"
+	gopass_qrcode "github.com/aviau/gopass/internal/qrcode"
+	gopass_otp "github.com/aviau/gopass/pkg/otp"
+	"github.com/aviau/gopass/pkg/secret"
 )
 
-var usernameRegex = regexp.MustCompile(`(username|user|email):\s*(?P<username>.*)`)
-var twoFactorRegex = regexp.MustCompile(`(2fa):\s*(?P<2fa>.*)`)
+// usernameKeys are the keys of the fields that can hold the username.
+var usernameKeys = []string{"username", "user", "email"}
+
+// findUsername returns the value of the first field holding a username.
+func findUsername(s *secret.Secret) (string, bool) {
+	for _, field := range s.Fields() {
+		for _, key := range usernameKeys {
+			if strings.EqualFold(field.Key, key) {
+				return field.Value, true
+			}
+		}
+	}
+	return "", false
+}
+
+// clipLineRegex matches pass's "-c2" and "--clip=2".
+var clipLineRegex = regexp.MustCompile(`^(-c|--clip=)([0-9]+)$`)
+
+// expandClipLine rewrites pass's "-cN" and "--clip=N" arguments as "--clip
+// --line=N", which the flag package can parse.
+func expandClipLine(args []string) []string {
+	var expanded []string
+	for i, arg := range args {
+		if arg == "--" {
+			return append(expanded, args[i:]...)
+		}
+		if matches := clipLineRegex.FindStringSubmatch(arg); matches != nil {
+			expanded = append(expanded, "--clip", "--line="+matches[2])
+			continue
+		}
+		expanded = append(expanded, arg)
+	}
+	return expanded
+}
 
 // execShow runs the "show" command.
-func execShow(cfg CommandConfig, args []string) error {
+func execShow(ctx context.Context, cfg CommandConfig, args []string) error {
 	var clip, c bool
 	var username, u bool
 	var help, h bool
 	var twoFactor, twoFa bool
+	var field, k string
+	var jsonOutput bool
+	var line int
+	var qrcode bool
+	var qrcodePNG string
 
 	fs := flag.NewFlagSet("show", flag.ContinueOnError)
 	fs.SetOutput(ioutil.Discard)
 
 	fs.Usage = func() {
-		fmt.Fprintln(cfg.WriterOutput(), "Usage: gopass show [pass-name]")
+		fmt.Fprintln(cfg.WriterOutput(), "Usage: gopass show [--clip[=line],-c[line]|--qrcode|--qrcode-png=file] [--line=line|--username,-u|--2fa|--field=key,-k key|--json] [pass-name]")
 	}
 
 	fs.BoolVar(&help, "help", false, "")
@@ -59,7 +101,17 @@ func execShow(cfg CommandConfig, args []string) error {
 	fs.BoolVar(&twoFactor, "two-factor", false, "")
 	fs.BoolVar(&twoFa, "2fa", false, "")
 
-	if err := fs.Parse(args); err != nil {
+	fs.StringVar(&field, "field", "", "")
+	fs.StringVar(&k, "k", "", "")
+
+	fs.BoolVar(&jsonOutput, "json", false, "")
+
+	fs.IntVar(&line, "line", 0, "")
+
+	fs.BoolVar(&qrcode, "qrcode", false, "")
+	fs.StringVar(&qrcodePNG, "qrcode-png", "", "")
+
+	if err := fs.Parse(expandClipLine(args)); err != nil {
 		return err
 	}
 
@@ -74,6 +126,22 @@ func execShow(cfg CommandConfig, args []string) error {
 
 	twoFactor = twoFactor || twoFa
 
+	if k != "" {
+		field = k
+	}
+
+	if jsonOutput && (clip || qrcode || qrcodePNG != "") {
+		return errors.New("--json can't be used with --clip, --qrcode or --qrcode-png")
+	}
+
+	if clip && qrcode {
+		return errors.New("--clip can't be used with --qrcode")
+	}
+
+	if line != 0 && (field != "" || username || twoFactor || jsonOutput) {
+		return errors.New("--line can't be used with --field, --username, --2fa or --json")
+	}
+
 	password := fs.Arg(0)
 
 	if password == "" {
@@ -83,63 +151,112 @@ func execShow(cfg CommandConfig, args []string) error {
 	store := cfg.PasswordStore()
 
 	// Decrypt the password
-	password, err := store.GetPassword(password)
+	password, err := store.GetPasswordContext(ctx, password)
 	if err != nil {
 		return err
 	}
 
+	entry := secret.Parse(password)
+
+	if jsonOutput {
+		output, err := json.MarshalIndent(entry, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cfg.WriterOutput(), string(output))
+		return nil
+	}
+
 	// Prepare the password to display or copy.
 	outputPassword := password
-	if username {
-		if matches := usernameRegex.FindStringSubmatch(password); matches != nil {
-			for i, name := range usernameRegex.SubexpNames() {
-				if name == "username" {
-					outputPassword = matches[i]
-					break
-				}
-			}
-		} else {
+	copied := "the first line"
+	if line != 0 {
+		var ok bool
+		if outputPassword, ok = entry.Line(line); !ok {
+			return fmt.Errorf("could not find line %d in the password, it has %d lines", line, entry.LineCount())
+		}
+		copied = fmt.Sprintf("line %d", line)
+	} else if field != "" {
+		var ok bool
+		if outputPassword, ok = entry.Get(field); !ok {
+			return fmt.Errorf("could not find field \"%s\" in the password", field)
+		}
+		copied = fmt.Sprintf("the field \"%s\"", field)
+	} else if username {
+		var ok bool
+		if outputPassword, ok = findUsername(entry); !ok {
 			return fmt.Errorf("could not find username in the password")
 		}
+		copied = "the username"
 	} else if twoFactor {
-		twoFactorSecret := ""
-
-		if matches := twoFactorRegex.FindStringSubmatch(password); matches != nil {
-			for i, name := range twoFactorRegex.SubexpNames() {
-				if name == "2fa" {
-					twoFactorSecret = matches[i]
-					break
-				}
-			}
-		} else {
-			return fmt.Errorf("could not find totp url in the password")
+		key, err := gopass_otp.FromSecret(entry)
+		if err != nil {
+			return err
 		}
-
-		if strings.HasPrefix(twoFactorSecret, "otpauth://") {
-			key, err := otp.NewKeyFromURL(twoFactorSecret)
-			if err != nil {
-				return fmt.Errorf("could not parse otpauth URL: %s", twoFactorSecret)
-			}
-			twoFactorSecret = key.Secret()
+		if key.Type == gopass_otp.TypeHOTP {
+			// Codes would be reused, since show does not save the counter.
+			return errors.New("--2fa can't be used with hotp keys, use \"gopass otp\" instead")
 		}
-
-		if outputPassword, err = totp.GenerateCode(twoFactorSecret, cfg.Now().UTC()); err != nil {
-			return fmt.Errorf("could not generate otp code: %w", err)
+		if outputPassword, err = key.Code(cfg.Now()); err != nil {
+			return err
 		}
-
-	} else if clip {
-		outputPassword = strings.Split(password, "\n")[0]
+		copied = "the otp code"
+	} else if clip || qrcode || qrcodePNG != "" {
+		outputPassword = entry.Password()
 	}
 
-	// Eithier display the password or copy it to the clipboard.
-	if clip {
-		if err := clipboard.CopyToClipboard(outputPassword); err != nil {
+	if qrcodePNG != "" {
+		if err := writeQRCodePNG(outputPassword, qrcodePNG); err != nil {
 			return err
 		}
-		fmt.Fprintln(cfg.WriterOutput(), "the first line of the password was copied to clipboard.")
+		fmt.Fprintf(cfg.WriterOutput(), "Wrote the QR code of %s of \"%s\" to %s.\n", copied, fs.Arg(0), qrcodePNG)
+		if !clip && !qrcode {
+			return nil
+		}
+	}
+
+	// Eithier display the password, copy it to the clipboard or show it as
+	// a QR code.
+	if qrcode {
+		return gopass_qrcode.Terminal(cfg.WriterOutput(), outputPassword)
+	} else if clip {
+		return copyToClipboard(cfg, outputPassword, fmt.Sprintf("%s of \"%s\"", copied, fs.Arg(0)))
 	} else {
 		fmt.Fprintln(cfg.WriterOutput(), outputPassword)
 	}
 
 	return nil
 }
+
+// writeQRCodePNG writes content as a QR code in a PNG image that only the
+// user can read.
+func writeQRCodePNG(content, filename string) error {
+	var image bytes.Buffer
+	if err := gopass_qrcode.PNG(&image, content, 512); err != nil {
+		return err
+	}
+	if err := gopass_io.WriteFileAtomic(filename, image.Bytes(), 0600); err != nil {
+		return fmt.Errorf("could not write the QR code: %w", err)
+	}
+	return nil
+}
+
+// copyToClipboard copies content to the clipboard, which is restored after
+// the clip time, and tells the user what was copied.
+func copyToClipboard(cfg CommandConfig, content, copied string) error {
+	provider, err := cfg.Clipboard()
+	if err != nil {
+		return err
+	}
+	clipTime := cfg.ClipTime()
+	restored, err := clipboard.CopyWithTimeout(provider, content, clipTime)
+	if err != nil {
+		return err
+	}
+	if restored {
+		fmt.Fprintf(cfg.WriterOutput(), "Copied %s to clipboard. Will clear in %d seconds.\n", copied, int(clipTime.Seconds()))
+	} else {
+		fmt.Fprintf(cfg.WriterOutput(), "Copied %s to clipboard.\n", copied)
+	}
+	return nil
+}
@@ -18,6 +18,9 @@
 package cli_test
 
 import (
+	"image/png"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -98,6 +101,39 @@ func TestShowTwoFactor(t *testing.T) {
 
 }
 
+func TestShowTwoFactorWithoutSpace(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	if err := cliTest.PasswordStore().InsertPassword("test.com", "pass123\n2fa:JBSWY3DPEHPK3PXP\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := cliTest.Run(
+		[]string{"show", "--2fa", "test.com"},
+		clitest.WithFixedTime(time.Date(2020, 1, 2, 15, 0, 0, 0, time.UTC)),
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "", result.Stderr.String())
+	assert.Equal(t, "891690\n", result.Stdout.String())
+}
+
+func TestShowUsernameWithoutSpace(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	if err := cliTest.PasswordStore().InsertPassword("test.com", "pass123\nusername:alice\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := cliTest.Run([]string{"show", "--username", "test.com"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "", result.Stderr.String())
+	assert.Equal(t, "alice\n", result.Stdout.String())
+}
+
 func TestShowTwoFactorOtpauthURI(t *testing.T) {
 	cliTest := clitest.NewCliTest(t)
 	defer cliTest.Close()
@@ -121,3 +157,143 @@ func TestShowTwoFactorOtpauthURI(t *testing.T) {
 	assert.Equal(t, result.Stdout.String(), "891690\n")
 
 }
+
+func TestShowTwoFactorParameters(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	passwordWithTwoFactor := `pass123
+2fa: otpauth://totp/test?secret=GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZA&digits=8&algorithm=SHA256&period=60
+`
+
+	if err := cliTest.PasswordStore().InsertPassword("test.com", passwordWithTwoFactor); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := cliTest.Run(
+		[]string{"show", "--2fa", "test.com"},
+		clitest.WithFixedTime(time.Unix(130, 0)),
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "", result.Stderr.String())
+	assert.Equal(t, "30882438\n", result.Stdout.String())
+}
+
+func TestShowField(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	if err := cliTest.PasswordStore().InsertPassword("test.com", "pass123\nurl: https://test.com\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := cliTest.Run([]string{"show", "-k", "url", "test.com"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "", result.Stderr.String())
+	assert.Equal(t, "https://test.com\n", result.Stdout.String())
+
+	_, err = cliTest.Run([]string{"show", "--field=missing", "test.com"})
+
+	assert.NotNil(t, err)
+}
+
+func TestShowJSON(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	if err := cliTest.PasswordStore().InsertPassword("test.com", "pass123\nusername: alice\nnotes\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := cliTest.Run([]string{"show", "--json", "test.com"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "", result.Stderr.String())
+	assert.JSONEq(
+		t,
+		`{"password": "pass123", "fields": [{"key": "username", "value": "alice"}], "body": "notes"}`,
+		result.Stdout.String(),
+	)
+}
+
+func TestShowLine(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	if err := cliTest.PasswordStore().InsertPassword("test.com", "pass123\nusername: alice\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := cliTest.Run([]string{"show", "--line=2", "test.com"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "", result.Stderr.String())
+	assert.Equal(t, "username: alice\n", result.Stdout.String())
+
+	for _, args := range [][]string{
+		{"show", "--line=3", "test.com"},
+		{"show", "-c3", "test.com"},
+		{"show", "--clip=3", "test.com"},
+	} {
+		_, err = cliTest.Run(args)
+		assert.EqualError(t, err, "could not find line 3 in the password, it has 2 lines", args)
+	}
+}
+
+func TestShowClip(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	if err := cliTest.PasswordStore().InsertPassword("test.com", "pass123\nurl: https://test.com\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		args      []string
+		clipboard string
+		output    string
+	}{
+		{[]string{"show", "-c", "test.com"}, "pass123", "Copied the first line of \"test.com\" to clipboard.\n"},
+		{[]string{"show", "-c2", "test.com"}, "url: https://test.com", "Copied line 2 of \"test.com\" to clipboard.\n"},
+		{[]string{"show", "-c", "-k", "url", "test.com"}, "https://test.com", "Copied the field \"url\" of \"test.com\" to clipboard.\n"},
+	} {
+		result, err := cliTest.Run(test.args)
+
+		assert.Nil(t, err)
+		assert.Equal(t, test.output, result.Stdout.String())
+		assert.Equal(t, test.clipboard, cliTest.Clipboard())
+	}
+}
+
+func TestShowQRCode(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	if err := cliTest.PasswordStore().InsertPassword("test.com", "pass123\nurl: https://test.com\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := cliTest.Run([]string{"show", "--qrcode", "test.com"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "", result.Stderr.String())
+	assert.True(t, strings.Contains(result.Stdout.String(), "█"))
+	assert.False(t, strings.Contains(result.Stdout.String(), "pass123"))
+
+	pngPath := filepath.Join(t.TempDir(), "qrcode.png")
+	result, err = cliTest.Run([]string{"show", "--qrcode-png", pngPath, "-k", "url", "test.com"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Wrote the QR code of the field \"url\" of \"test.com\" to "+pngPath+".\n", result.Stdout.String())
+
+	file, err := os.Open(pngPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	_, err = png.Decode(file)
+	assert.Nil(t, err)
+}
@@ -24,8 +24,11 @@ import (
 	"os"
 	"os/exec"
 	"path"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/aviau/gopass/internal/clipboard"
 	"github.com/aviau/gopass/pkg/store"
 )
 
@@ -38,6 +41,8 @@ type CommandConfig interface {
 	PasswordStoreDir() string
 	PasswordStore() *store.PasswordStore
 	Now() time.Time
+	ClipTime() time.Duration
+	Clipboard() (clipboard.Provider, error)
 }
 
 // DefaultConfig is a default CommandConfig implementation.
@@ -85,6 +90,8 @@ func (cfg *DefaultConfig) PasswordStoreDir() string {
 func (cfg *DefaultConfig) PasswordStore() *store.PasswordStore {
 	storePath := cfg.PasswordStoreDir()
 	s := store.NewPasswordStore(storePath)
+	s.VCS = store.NewGitVCS(s.Path, s.GitDir, cfg.WriterOutput(), cfg.WriterError())
+	s.SigningKeys = strings.Fields(os.Getenv("PASSWORD_STORE_SIGNING_KEY"))
 	return s
 }
 
@@ -133,3 +140,18 @@ func (cfg *DefaultConfig) Edit(content string) (string, error) {
 func (cfg *DefaultConfig) Now() time.Time {
 	return time.Now()
 }
+
+// ClipTime returns how long copied passwords stay in the clipboard.
+func (cfg *DefaultConfig) ClipTime() time.Duration {
+	seconds, err := strconv.Atoi(os.Getenv("PASSWORD_STORE_CLIP_TIME"))
+	if err != nil || seconds < 0 {
+		seconds = 45
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// Clipboard returns the clipboard provider named by PASSWORD_STORE_CLIPBOARD
+// or detected from the environment.
+func (cfg *DefaultConfig) Clipboard() (clipboard.Provider, error) {
+	return clipboard.Detect(os.Getenv)
+}
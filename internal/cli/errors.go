@@ -0,0 +1,96 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aviau/gopass/pkg/store"
+)
+
+// Exit codes of the gopass CLI.
+const (
+	ExitSuccess        = 0
+	ExitFailure        = 1
+	ExitNotInitialized = 3
+	ExitNotFound       = 4
+	ExitAlreadyExists  = 5
+	ExitDecrypt        = 6
+	ExitEncrypt        = 7
+	ExitInvalidName    = 8
+	ExitInterrupted    = 130
+)
+
+// ExitCode returns the exit code to use for an error returned by Run.
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return ExitSuccess
+	case errors.Is(err, context.Canceled):
+		return ExitInterrupted
+	case errors.Is(err, store.ErrNotInitialized):
+		return ExitNotInitialized
+	case errors.Is(err, store.ErrNotFound):
+		return ExitNotFound
+	case errors.Is(err, store.ErrAlreadyExists):
+		return ExitAlreadyExists
+	case errors.Is(err, store.ErrDecrypt):
+		return ExitDecrypt
+	case errors.Is(err, store.ErrEncrypt):
+		return ExitEncrypt
+	case errors.Is(err, store.ErrInvalidName):
+		return ExitInvalidName
+	default:
+		return ExitFailure
+	}
+}
+
+// commandError is an error with its own message that wraps one of the
+// store's errors, so that it maps to the same exit code.
+type commandError struct {
+	message string
+	err     error
+}
+
+func (e *commandError) Error() string {
+	return e.message
+}
+
+func (e *commandError) Unwrap() error {
+	return e.err
+}
+
+// newCommandError returns a commandError wrapping err.
+func newCommandError(err error, format string, a ...interface{}) error {
+	return &commandError{
+		message: fmt.Sprintf(format, a...),
+		err:     err,
+	}
+}
+
+// notFoundError returns the error of a command whose argument is neither a
+// password nor a directory. It is the store's error if the name is invalid,
+// or a not found error with the given message.
+func notFoundError(passwordStore *store.PasswordStore, name string, format string, a ...interface{}) error {
+	if _, err := passwordStore.FindDirectory(name); err != nil && !errors.Is(err, store.ErrNotFound) {
+		return err
+	}
+	return newCommandError(store.ErrNotFound, format, a...)
+}
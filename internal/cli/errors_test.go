@@ -0,0 +1,90 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package cli_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aviau/gopass/internal/cli"
+	"github.com/aviau/gopass/internal/cli/clitest"
+	"github.com/aviau/gopass/pkg/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, cli.ExitSuccess, cli.ExitCode(nil))
+	assert.Equal(t, cli.ExitFailure, cli.ExitCode(errors.New("failure")))
+	assert.Equal(t, cli.ExitNotInitialized, cli.ExitCode(store.ErrNotInitialized))
+	assert.Equal(t, cli.ExitNotFound, cli.ExitCode(fmt.Errorf("wrapped: %w", store.ErrNotFound)))
+	assert.Equal(t, cli.ExitAlreadyExists, cli.ExitCode(store.ErrAlreadyExists))
+	assert.Equal(t, cli.ExitDecrypt, cli.ExitCode(&store.EntryError{Kind: "password", Name: "test.com", Err: store.ErrDecrypt}))
+	assert.Equal(t, cli.ExitEncrypt, cli.ExitCode(store.ErrEncrypt))
+	assert.Equal(t, cli.ExitInvalidName, cli.ExitCode(store.ErrInvalidName))
+	assert.Equal(t, cli.ExitInterrupted, cli.ExitCode(fmt.Errorf("wrapped: %w", context.Canceled)))
+}
+
+func TestShowUnexistingPasswordExitCode(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	_, err := cliTest.Run([]string{"show", "test.com"})
+
+	assert.EqualError(t, err, "password \"test.com\": not found")
+	assert.Equal(t, cli.ExitNotFound, cli.ExitCode(err))
+}
+
+func TestShowOutsideOfStoreExitCode(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	_, err := cliTest.Run([]string{"show", "../../etc/passwd"})
+
+	assert.EqualError(t, err, "password \"../../etc/passwd\": invalid name")
+	assert.Equal(t, cli.ExitInvalidName, cli.ExitCode(err))
+}
+
+func TestInvalidSourceExitCode(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	for _, args := range [][]string{
+		{"mv", "../x", "y"},
+		{"cp", "../x", "y"},
+		{"rm", "-r", ".."},
+	} {
+		_, err := cliTest.Run(args)
+		assert.True(t, errors.Is(err, store.ErrInvalidName), args)
+		assert.Equal(t, cli.ExitInvalidName, cli.ExitCode(err), args)
+	}
+}
+
+func TestMissingSourceExitCode(t *testing.T) {
+	cliTest := clitest.NewCliTest(t)
+	defer cliTest.Close()
+
+	_, err := cliTest.Run([]string{"mv", "missing", "y"})
+	assert.EqualError(t, err, "could not find source \"missing\" to move")
+	assert.Equal(t, cli.ExitNotFound, cli.ExitCode(err))
+
+	_, err = cliTest.Run([]string{"cp", "missing", "y"})
+	assert.EqualError(t, err, "could not find source \"missing\" to copy")
+	assert.Equal(t, cli.ExitNotFound, cli.ExitCode(err))
+}
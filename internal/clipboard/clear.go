@@ -0,0 +1,234 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package clipboard
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+)
+
+// ClearHelperCommand is the hidden gopass command that runs the helper
+// started by CopyWithTimeout. It must call RunClearHelper.
+const ClearHelperCommand = "__clear-clipboard"
+
+// clearRequest is what CopyWithTimeout sends to the helper on its stdin, so
+// that secrets never appear in its arguments or environment.
+type clearRequest struct {
+	Provider string        `json:"provider"` // The name of the Provider
+	Timeout  time.Duration `json:"timeout"`
+	Checksum string        `json:"checksum"` // The SHA-256 of what was copied
+	Previous string        `json:"previous"` // The clipboard before the copy
+}
+
+// checksum returns the hexadecimal SHA-256 of s.
+func checksum(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// clearHelperSocket returns the path of the socket through which a new copy
+// stops the pending helper.
+func clearHelperSocket() string {
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, fmt.Sprintf("gopass-clipboard-%d.sock", os.Getuid()))
+}
+
+// CopyWithTimeout copies s to the clipboard and starts a detached helper
+// that restores the previous content of the clipboard after timeout, unless
+// something else was copied in the meantime. It returns whether or not the
+// clipboard will be restored, which is not the case if timeout is not
+// positive or if the provider can't read the clipboard.
+func CopyWithTimeout(provider Provider, s string, timeout time.Duration) (bool, error) {
+	socket := clearHelperSocket()
+	return copyWithTimeout(
+		provider,
+		s,
+		timeout,
+		func() { stopClearHelper(socket) },
+		startClearHelper,
+	)
+}
+
+// copyWithTimeout is CopyWithTimeout with the functions that stop the
+// pending helper and start a new one.
+func copyWithTimeout(provider Provider, s string, timeout time.Duration, stopHelper func(), startHelper func(clearRequest) error) (bool, error) {
+	// Like pass, the pending helper restores the clipboard right away. What
+	// it copied must not be restored as the previous content.
+	stopHelper()
+
+	// The clipboard may be empty, in which case it will be emptied.
+	previous, err := provider.Paste()
+	canRestore := !errors.Is(err, ErrPasteUnsupported)
+
+	if err := provider.Copy(s); err != nil {
+		return false, err
+	}
+
+	if timeout <= 0 || !canRestore {
+		return false, nil
+	}
+
+	err = startHelper(clearRequest{
+		Provider: provider.Name(),
+		Timeout:  timeout,
+		Checksum: checksum(s),
+		Previous: previous,
+	})
+
+	return err == nil, err
+}
+
+// startClearHelper runs the clear helper in the background, detached from
+// the terminal so that it outlives gopass.
+func startClearHelper(request clearRequest) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("could not find the gopass executable: %w", err)
+	}
+
+	helper := exec.Command(executable, ClearHelperCommand)
+	detach(helper)
+
+	stdin, err := helper.StdinPipe()
+	if err != nil {
+		return err
+	}
+
+	if err := helper.Start(); err != nil {
+		return fmt.Errorf("could not start the clipboard helper: %w", err)
+	}
+
+	if err := json.NewEncoder(stdin).Encode(request); err != nil {
+		return fmt.Errorf("could not write to the clipboard helper: %w", err)
+	}
+
+	if err := stdin.Close(); err != nil {
+		return err
+	}
+
+	return helper.Process.Release()
+}
+
+// stopClearHelper makes the helper listening on socket, if any, restore the
+// clipboard now and waits until it did.
+func stopClearHelper(socket string) {
+	conn, err := net.DialTimeout("unix", socket, time.Second)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	// The helper closes the connection once the clipboard is restored.
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, _ = io.Copy(ioutil.Discard, conn)
+}
+
+// listenClearHelper listens on socket for new copies that stop the helper.
+func listenClearHelper(socket string) (net.Listener, error) {
+	// The socket of a helper that is gone is left behind.
+	if err := os.Remove(socket); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return net.Listen("unix", socket)
+}
+
+// RunClearHelper reads a request written by CopyWithTimeout, waits for its
+// timeout and restores the previous content of the clipboard if it still
+// holds what was copied.
+func RunClearHelper(r io.Reader) error {
+	var request clearRequest
+	if err := json.NewDecoder(r).Decode(&request); err != nil {
+		return fmt.Errorf("could not read the clipboard helper request: %w", err)
+	}
+
+	provider, err := ByName(request.Provider, os.Getenv)
+	if err != nil {
+		return err
+	}
+
+	// The clipboard is still restored after the timeout if no new copy can
+	// stop the helper.
+	listener, err := listenClearHelper(clearHelperSocket())
+	if err != nil {
+		listener = nil
+	}
+
+	return runClearHelper(listener, provider, request)
+}
+
+// runClearHelper restores the clipboard of provider after the timeout of
+// request, or as soon as a connection is made to listener.
+func runClearHelper(listener net.Listener, provider Provider, request clearRequest) error {
+	if listener == nil {
+		return restoreAfter(provider, request.Timeout, nil, request)
+	}
+	defer listener.Close()
+
+	stop := make(chan struct{})
+	conns := make(chan net.Conn, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		conns <- conn
+		close(stop)
+	}()
+
+	err := restoreAfter(provider, request.Timeout, stop, request)
+
+	// Let the new copy know that the clipboard was restored.
+	select {
+	case conn := <-conns:
+		conn.Close()
+	default:
+	}
+
+	return err
+}
+
+// restoreAfter waits for timeout, or until stop is closed, and restores the
+// previous content of the clipboard of provider if it still holds what was
+// copied.
+func restoreAfter(provider Provider, timeout time.Duration, stop <-chan struct{}, request clearRequest) error {
+	select {
+	case <-time.After(timeout):
+	case <-stop:
+	}
+
+	current, err := provider.Paste()
+	if err != nil || checksum(current) != request.Checksum {
+		// Something else owns the clipboard now.
+		return nil
+	}
+
+	return provider.Copy(request.Previous)
+}
@@ -0,0 +1,168 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package clipboard
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryProvider is a Provider whose clipboard is a string.
+type memoryProvider struct {
+	mu       sync.Mutex
+	content  string
+	pasteErr error
+}
+
+func (p *memoryProvider) Name() string {
+	return "memory"
+}
+
+func (p *memoryProvider) Copy(s string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.content = s
+	return nil
+}
+
+func (p *memoryProvider) Paste() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.content, p.pasteErr
+}
+
+func (p *memoryProvider) String() string {
+	content, _ := p.Paste()
+	return content
+}
+
+func noHelper() {}
+
+// copyForRestore copies s to provider and returns the request that would be
+// sent to the helper.
+func copyForRestore(t *testing.T, provider Provider, s string) clearRequest {
+	var request clearRequest
+	restored, err := copyWithTimeout(provider, s, time.Minute, noHelper, func(r clearRequest) error {
+		request = r
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.True(t, restored)
+	return request
+}
+
+func TestCopyWithTimeoutRequest(t *testing.T) {
+	provider := &memoryProvider{content: "previous"}
+
+	request := copyForRestore(t, provider, "secret")
+	assert.Equal(t, "secret", provider.String())
+	assert.Equal(t, clearRequest{
+		Provider: "memory",
+		Timeout:  time.Minute,
+		Checksum: checksum("secret"),
+		Previous: "previous",
+	}, request)
+	assert.NotContains(t, request.Checksum, "secret")
+}
+
+func TestCopyWithTimeoutPasteUnsupported(t *testing.T) {
+	provider := &memoryProvider{pasteErr: ErrPasteUnsupported}
+
+	restored, err := copyWithTimeout(provider, "secret", time.Minute, noHelper, func(clearRequest) error {
+		t.Error("the helper should not have been started")
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.False(t, restored)
+	assert.Equal(t, "secret", provider.String())
+}
+
+func TestCopyWithoutTimeout(t *testing.T) {
+	provider := &memoryProvider{content: "previous"}
+
+	restored, err := copyWithTimeout(provider, "secret", 0, noHelper, func(clearRequest) error {
+		t.Error("the helper should not have been started")
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.False(t, restored)
+	assert.Equal(t, "secret", provider.String())
+}
+
+func TestRestoreAfter(t *testing.T) {
+	provider := &memoryProvider{content: "previous"}
+	request := copyForRestore(t, provider, "secret")
+
+	assert.Nil(t, restoreAfter(provider, time.Millisecond, nil, request))
+	assert.Equal(t, "previous", provider.String())
+}
+
+func TestRestoreAfterSomethingElseWasCopied(t *testing.T) {
+	provider := &memoryProvider{content: "previous"}
+	request := copyForRestore(t, provider, "secret")
+
+	if err := provider.Copy("other"); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, restoreAfter(provider, time.Millisecond, nil, request))
+	assert.Equal(t, "other", provider.String())
+}
+
+func TestCopyWithTimeoutTwice(t *testing.T) {
+	provider := &memoryProvider{content: "previous"}
+	socket := filepath.Join(t.TempDir(), "clipboard.sock")
+
+	var helpers sync.WaitGroup
+	defer helpers.Wait()
+
+	copySecret := func(secret string) {
+		restored, err := copyWithTimeout(
+			provider,
+			secret,
+			5*time.Second,
+			func() { stopClearHelper(socket) },
+			func(request clearRequest) error {
+				listener, err := listenClearHelper(socket)
+				if err != nil {
+					return err
+				}
+				helpers.Add(1)
+				go func() {
+					defer helpers.Done()
+					assert.Nil(t, runClearHelper(listener, provider, request))
+				}()
+				return nil
+			},
+		)
+		assert.Nil(t, err)
+		assert.True(t, restored)
+	}
+
+	copySecret("first secret")
+	copySecret("second secret")
+	assert.Equal(t, "second secret", provider.String())
+
+	// The second helper restores what was there before the first copy.
+	stopClearHelper(socket)
+	assert.Equal(t, "previous", provider.String())
+}
@@ -19,37 +19,116 @@
 package clipboard
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
 	"os/exec"
 )
 
-// CopyToClipboard copies a string to the clipboard using xclip
-func CopyToClipboard(s string) error {
-	xclip := exec.Command(
-		"xclip",
-		"-in",
-		"-selection",
-		"clipboard",
-	)
+// ErrPasteUnsupported means that a Provider can't read the clipboard.
+var ErrPasteUnsupported = errors.New("the clipboard can't be read")
 
-	stdin, err := xclip.StdinPipe()
+// Provider copies to and reads from a clipboard.
+type Provider interface {
+	// Name returns the name of the provider, as accepted by ByName.
+	Name() string
+
+	// Copy replaces the content of the clipboard.
+	Copy(s string) error
+
+	// Paste returns the content of the clipboard. It fails with
+	// ErrPasteUnsupported if the provider can only copy.
+	Paste() (string, error)
+}
+
+// commandProvider is a Provider that runs a command to copy and another one
+// to paste.
+type commandProvider struct {
+	name  string
+	copy  []string
+	paste []string
+}
+
+func (p *commandProvider) Name() string {
+	return p.name
+}
+
+func (p *commandProvider) Copy(s string) error {
+	// xclip and wl-copy fork a child that keeps serving the clipboard and
+	// inherits stderr. It must be a file, as Run would wait for the child to
+	// close a pipe.
+	stderr, err := ioutil.TempFile("", "gopass-clipboard-")
 	if err != nil {
 		return err
 	}
+	defer os.Remove(stderr.Name())
+	defer stderr.Close()
 
-	if err := xclip.Start(); err != nil {
-		return err
+	cmd := exec.Command(p.copy[0], p.copy[1:]...)
+	cmd.Stdin = bytes.NewReader([]byte(s))
+	cmd.Stderr = stderr
+
+	if err := cmd.Run(); err != nil {
+		output, _ := ioutil.ReadFile(stderr.Name())
+		return fmt.Errorf("%s: %w: %s", p.copy[0], err, output)
 	}
 
-	_, err = stdin.Write([]byte(s))
+	return nil
+}
+
+func (p *commandProvider) Paste() (string, error) {
+	var stderr bytes.Buffer
+
+	cmd := exec.Command(p.paste[0], p.paste[1:]...)
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
 	if err != nil {
-		return err
+		return "", fmt.Errorf("%s: %w: %s", p.paste[0], err, stderr.Bytes())
 	}
 
-	if err := stdin.Close(); err != nil {
-		return err
+	return string(output), nil
+}
+
+// newWaylandProvider returns a Provider using wl-copy and wl-paste.
+func newWaylandProvider(selection string) Provider {
+	p := &commandProvider{
+		name:  "wayland",
+		copy:  []string{"wl-copy"},
+		paste: []string{"wl-paste", "--no-newline"},
+	}
+	if selection == "primary" {
+		p.copy = append(p.copy, "--primary")
+		p.paste = append(p.paste, "--primary")
 	}
+	return p
+}
 
-	err = xclip.Wait()
+// newXclipProvider returns a Provider using xclip on an X selection.
+func newXclipProvider(selection string) Provider {
+	return &commandProvider{
+		name:  "xclip",
+		copy:  []string{"xclip", "-in", "-selection", selection},
+		paste: []string{"xclip", "-out", "-selection", selection},
+	}
+}
+
+// newXselProvider returns a Provider using xsel on an X selection.
+func newXselProvider(selection string) Provider {
+	return &commandProvider{
+		name:  "xsel",
+		copy:  []string{"xsel", "--" + selection, "--input"},
+		paste: []string{"xsel", "--" + selection, "--output"},
+	}
+}
 
-	return err
+// newTmuxProvider returns a Provider using tmux's paste buffer.
+func newTmuxProvider() Provider {
+	return &commandProvider{
+		name:  "tmux",
+		copy:  []string{"tmux", "load-buffer", "-"},
+		paste: []string{"tmux", "save-buffer", "-"},
+	}
 }
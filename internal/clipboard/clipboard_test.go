@@ -0,0 +1,72 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package clipboard
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandProviderCopyDoesNotWaitForChildren(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake copy command is a shell script")
+	}
+
+	// Like xclip, the command forks a child that outlives it.
+	dir := t.TempDir()
+	command := filepath.Join(dir, "copy")
+	if err := ioutil.WriteFile(command, []byte("#!/bin/sh\ncat > \"$1\"\nsleep 20 &\n"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	clipboardPath := filepath.Join(dir, "clipboard")
+	provider := &commandProvider{
+		name: "fake",
+		copy: []string{command, clipboardPath},
+	}
+
+	start := time.Now()
+	assert.Nil(t, provider.Copy("password"))
+	assert.Less(t, time.Since(start), 10*time.Second)
+
+	content, err := ioutil.ReadFile(clipboardPath)
+	assert.Nil(t, err)
+	assert.Equal(t, "password", string(content))
+}
+
+func TestCommandProviderCopyError(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake copy command is a shell script")
+	}
+
+	command := filepath.Join(t.TempDir(), "copy")
+	if err := ioutil.WriteFile(command, []byte("#!/bin/sh\necho 'no display' >&2\nexit 1\n"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	provider := &commandProvider{name: "fake", copy: []string{command}}
+
+	err := provider.Copy("password")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "no display")
+}
@@ -0,0 +1,101 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package clipboard
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+)
+
+// ErrNoProvider means that no clipboard provider works in the environment.
+var ErrNoProvider = errors.New("no clipboard provider found, install wl-clipboard, xclip or xsel")
+
+// Providers are the names of the providers accepted by ByName.
+var Providers = []string{"wayland", "xclip", "xsel", "tmux", "osc52"}
+
+// ByName returns the provider with the given name. getenv is used to read
+// the X selection from PASSWORD_STORE_X_SELECTION, which defaults to
+// "clipboard".
+func ByName(name string, getenv func(string) string) (Provider, error) {
+	selection := getenv("PASSWORD_STORE_X_SELECTION")
+	switch selection {
+	case "":
+		selection = "clipboard"
+	case "clipboard", "primary", "secondary":
+	default:
+		return nil, fmt.Errorf("invalid PASSWORD_STORE_X_SELECTION \"%s\"", selection)
+	}
+
+	switch name {
+	case "wayland":
+		return newWaylandProvider(selection), nil
+	case "xclip":
+		return newXclipProvider(selection), nil
+	case "xsel":
+		return newXselProvider(selection), nil
+	case "tmux":
+		return newTmuxProvider(), nil
+	case "osc52":
+		return newOSC52Provider(getenv("TMUX") != ""), nil
+	default:
+		return nil, fmt.Errorf("unknown clipboard provider \"%s\"", name)
+	}
+}
+
+// Detect returns the provider named by PASSWORD_STORE_CLIPBOARD, or the
+// first one that works in the environment read with getenv: Wayland, then
+// X11 with xclip or xsel, then tmux's paste buffer and finally OSC 52 when
+// connected with SSH.
+func Detect(getenv func(string) string) (Provider, error) {
+	if name := getenv("PASSWORD_STORE_CLIPBOARD"); name != "" {
+		return ByName(name, getenv)
+	}
+
+	name := detectName(getenv, hasCommand)
+	if name == "" {
+		return nil, ErrNoProvider
+	}
+
+	return ByName(name, getenv)
+}
+
+// detectName returns the name of the provider to use. hasCommand tells
+// whether or not a command is installed.
+func detectName(getenv func(string) string, hasCommand func(string) bool) string {
+	switch {
+	case getenv("WAYLAND_DISPLAY") != "" && hasCommand("wl-copy"):
+		return "wayland"
+	case getenv("DISPLAY") != "" && hasCommand("xclip"):
+		return "xclip"
+	case getenv("DISPLAY") != "" && hasCommand("xsel"):
+		return "xsel"
+	case getenv("TMUX") != "" && hasCommand("tmux"):
+		return "tmux"
+	case getenv("SSH_TTY") != "":
+		return "osc52"
+	default:
+		return ""
+	}
+}
+
+// hasCommand returns whether or not a command is in the PATH.
+func hasCommand(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
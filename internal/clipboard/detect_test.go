@@ -0,0 +1,120 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package clipboard
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func env(vars map[string]string) func(string) string {
+	return func(name string) string {
+		return vars[name]
+	}
+}
+
+func TestDetectName(t *testing.T) {
+	all := func(string) bool { return true }
+	only := func(commands ...string) func(string) bool {
+		return func(name string) bool {
+			for _, command := range commands {
+				if name == command {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	for _, test := range []struct {
+		env        map[string]string
+		hasCommand func(string) bool
+		expected   string
+	}{
+		{map[string]string{"WAYLAND_DISPLAY": "wayland-0", "DISPLAY": ":0"}, all, "wayland"},
+		{map[string]string{"WAYLAND_DISPLAY": "wayland-0", "DISPLAY": ":0"}, only("xsel"), "xsel"},
+		{map[string]string{"DISPLAY": ":0"}, all, "xclip"},
+		{map[string]string{"DISPLAY": ":0", "TMUX": "/tmp/tmux"}, only("tmux"), "tmux"},
+		{map[string]string{"TMUX": "/tmp/tmux", "SSH_TTY": "/dev/pts/0"}, all, "tmux"},
+		{map[string]string{"SSH_TTY": "/dev/pts/0"}, all, "osc52"},
+		{map[string]string{}, all, ""},
+	} {
+		assert.Equal(t, test.expected, detectName(env(test.env), test.hasCommand), test.env)
+	}
+}
+
+func TestDetectExplicitProvider(t *testing.T) {
+	provider, err := Detect(env(map[string]string{"PASSWORD_STORE_CLIPBOARD": "xsel", "WAYLAND_DISPLAY": "wayland-0"}))
+	assert.Nil(t, err)
+	assert.Equal(t, "xsel", provider.Name())
+
+	_, err = Detect(env(map[string]string{"PASSWORD_STORE_CLIPBOARD": "unknown"}))
+	assert.NotNil(t, err)
+}
+
+func TestByNameSelection(t *testing.T) {
+	provider, err := ByName("xclip", env(map[string]string{"PASSWORD_STORE_X_SELECTION": "primary"}))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"xclip", "-in", "-selection", "primary"}, provider.(*commandProvider).copy)
+
+	provider, err = ByName("xsel", env(map[string]string{}))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"xsel", "--clipboard", "--input"}, provider.(*commandProvider).copy)
+
+	provider, err = ByName("wayland", env(map[string]string{"PASSWORD_STORE_X_SELECTION": "primary"}))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"wl-copy", "--primary"}, provider.(*commandProvider).copy)
+
+	_, err = ByName("xclip", env(map[string]string{"PASSWORD_STORE_X_SELECTION": "invalid"}))
+	assert.NotNil(t, err)
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+func TestOSC52(t *testing.T) {
+	for _, test := range []struct {
+		tmux     bool
+		expected string
+	}{
+		{false, "\x1b]52;c;cGFzc3dvcmQ=\a"},
+		{true, "\x1bPtmux;\x1b\x1b]52;c;cGFzc3dvcmQ=\a\x1b\\"},
+	} {
+		var terminal bytes.Buffer
+		provider := &osc52Provider{
+			tmux: test.tmux,
+			openTerminal: func() (io.WriteCloser, error) {
+				return nopCloser{&terminal}, nil
+			},
+		}
+
+		assert.Nil(t, provider.Copy("password"))
+		assert.Equal(t, test.expected, terminal.String())
+
+		_, err := provider.Paste()
+		assert.Equal(t, ErrPasteUnsupported, err)
+	}
+}
@@ -0,0 +1,72 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package clipboard
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+)
+
+// osc52Provider is a Provider that asks the terminal to set the clipboard
+// with the OSC 52 escape sequence. It works over SSH, but can't read the
+// clipboard.
+type osc52Provider struct {
+	// tmux is whether or not the terminal is tmux, which only passes the
+	// sequence through to the outer terminal if it is wrapped.
+	tmux bool
+
+	// openTerminal opens the terminal to write the sequence to.
+	openTerminal func() (io.WriteCloser, error)
+}
+
+// newOSC52Provider returns a Provider writing OSC 52 sequences to the
+// controlling terminal.
+func newOSC52Provider(tmux bool) Provider {
+	return &osc52Provider{
+		tmux: tmux,
+		openTerminal: func() (io.WriteCloser, error) {
+			return os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+		},
+	}
+}
+
+func (p *osc52Provider) Name() string {
+	return "osc52"
+}
+
+func (p *osc52Provider) Copy(s string) error {
+	sequence := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(s)) + "\a"
+	if p.tmux {
+		sequence = "\x1bPtmux;\x1b" + sequence + "\x1b\\"
+	}
+
+	terminal, err := p.openTerminal()
+	if err != nil {
+		return fmt.Errorf("could not open the terminal: %w", err)
+	}
+	defer terminal.Close()
+
+	_, err = io.WriteString(terminal, sequence)
+	return err
+}
+
+func (p *osc52Provider) Paste() (string, error) {
+	return "", ErrPasteUnsupported
+}
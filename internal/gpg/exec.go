@@ -3,9 +3,13 @@ package gpg
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"os/exec"
+	"strings"
 )
 
 type gpg struct {
@@ -29,13 +33,17 @@ func New(gpgBin string, env []string, alwaysTrust bool) *gpg {
 	}
 }
 
-func (gpg *gpg) cmd(args ...string) *exec.Cmd {
-	cmd := exec.Command(gpg.gpgBin, args...)
+func (gpg *gpg) cmd(ctx context.Context, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, gpg.gpgBin, args...)
 	cmd.Env = append(os.Environ(), gpg.env...)
 	return cmd
 }
 
 func (gpg *gpg) Encrypt(content []byte, recipients []string) ([]byte, error) {
+	return gpg.EncryptContext(context.Background(), content, recipients)
+}
+
+func (gpg *gpg) EncryptContext(ctx context.Context, content []byte, recipients []string) ([]byte, error) {
 	gpgArgs := []string{
 		"--encrypt",
 		"--batch",
@@ -57,105 +65,298 @@ func (gpg *gpg) Encrypt(content []byte, recipients []string) ([]byte, error) {
 	var stdout bytes.Buffer
 	var stderr bytes.Buffer
 
-	cmd := gpg.cmd(gpgArgs...)
+	cmd := gpg.cmd(ctx, gpgArgs...)
+	cmd.Stdin = bytes.NewReader(content)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, fmt.Errorf("%w: %s", err, stderr.Bytes())
+	}
+
+	return stdout.Bytes(), nil
+}
+
+func (gpg *gpg) Decrypt(content []byte) ([]byte, error) {
+	return gpg.DecryptContext(context.Background(), content)
+}
+
+func (gpg *gpg) DecryptContext(ctx context.Context, content []byte) ([]byte, error) {
+	gpgArgs := []string{
+		"--decrypt",
+		"--batch",
+		"--use-agent",
+		"--no-tty",
+		"--quiet",
+		"--output", "-",
+	}
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	cmd := gpg.cmd(ctx, gpgArgs...)
+	cmd.Stdin = bytes.NewReader(content)
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
+	if err := cmd.Run(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, fmt.Errorf("%w: %s", err, stderr.Bytes())
+	}
+
+	return stdout.Bytes(), nil
+}
+
+func (gpg *gpg) Import(content []byte) error {
+	gpgArgs := []string{
+		"--import",
+	}
+
+	var stderr bytes.Buffer
+
+	cmd := gpg.cmd(context.Background(), gpgArgs...)
+	cmd.Stderr = &stderr
+
 	stdin, err := cmd.StdinPipe()
 	if err != nil {
-		return nil, fmt.Errorf("could not get gpg's stdin: %w", err)
+		return fmt.Errorf("could not get gpg's stdin: %w", err)
 	}
 
 	if err := cmd.Start(); err != nil {
-		return nil, fmt.Errorf("could not start gpg: %w", err)
+		return fmt.Errorf("could not start gpg: %w", err)
 	}
 
 	if _, err := stdin.Write(content); err != nil {
-		return nil, fmt.Errorf("could not write to gpg's stdin: %w", err)
+		return fmt.Errorf("could not write to gpg's stdin: %w", err)
 	}
 
 	if err := stdin.Close(); err != nil {
-		return nil, fmt.Errorf("could not close gpg's stdin: %w", err)
+		return fmt.Errorf("could not close gpg's stdin: %w", err)
 	}
 
 	if err := cmd.Wait(); err != nil {
-		return nil, fmt.Errorf("%w: %s", err, stderr.Bytes())
+		return fmt.Errorf("%w: %s", err, stderr.Bytes())
 	}
 
-	return stdout.Bytes(), nil
+	return nil
 }
 
-func (gpg *gpg) Decrypt(content []byte) ([]byte, error) {
+func (gpg *gpg) Recipients(ctx context.Context, content []byte) ([]string, error) {
 	gpgArgs := []string{
-		"--decrypt",
 		"--batch",
-		"--use-agent",
 		"--no-tty",
-		"--quiet",
-		"--output", "-",
+		"--list-only",
+		"--list-packets",
 	}
 
 	var stdout bytes.Buffer
 	var stderr bytes.Buffer
 
-	cmd := gpg.cmd(gpgArgs...)
+	cmd := gpg.cmd(ctx, gpgArgs...)
+	cmd.Stdin = bytes.NewReader(content)
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
-	stdin, err := cmd.StdinPipe()
-	if err != nil {
-		return nil, fmt.Errorf("could not get gpg's stdin: %w", err)
+	// gpg fails when it has none of the secret keys, but still lists the
+	// packets.
+	runErr := cmd.Run()
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
 	}
 
-	if err := cmd.Start(); err != nil {
-		return nil, fmt.Errorf("could not start gpg: %w", err)
+	var recipients []string
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		if !strings.HasPrefix(line, ":pubkey enc packet:") {
+			continue
+		}
+		fields := strings.Fields(line)
+		for i, field := range fields {
+			if field == "keyid" && i+1 < len(fields) {
+				recipients = append(recipients, fields[i+1])
+			}
+		}
 	}
 
-	if _, err := stdin.Write(content); err != nil {
-		return nil, fmt.Errorf("could not write to gpg's stdin: %w", err)
+	if len(recipients) == 0 && runErr != nil {
+		return nil, fmt.Errorf("%w: %s", runErr, stderr.Bytes())
 	}
 
-	if err := stdin.Close(); err != nil {
-		return nil, fmt.Errorf("could not close gpg's stdin: %w", err)
+	return recipients, nil
+}
+
+// listKeys returns the pub, sub and uid records of gpg's colon listing of
+// the keys matching id.
+func (gpg *gpg) listKeys(ctx context.Context, id string) ([][]string, error) {
+	gpgArgs := []string{
+		"--batch",
+		"--no-tty",
+		"--with-colons",
+		"--list-keys",
+		"--", id,
 	}
 
-	if err := cmd.Wait(); err != nil {
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	cmd := gpg.cmd(ctx, gpgArgs...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
 		return nil, fmt.Errorf("%w: %s", err, stderr.Bytes())
 	}
 
-	return stdout.Bytes(), err
+	var records [][]string
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		fields := strings.Split(line, ":")
+		if len(fields) < 10 {
+			continue
+		}
+		switch fields[0] {
+		case "pub", "sub", "uid":
+			records = append(records, fields)
+		}
+	}
+
+	return records, nil
 }
 
-func (gpg *gpg) Import(content []byte) error {
+func (gpg *gpg) KeyIDs(ctx context.Context, gpgID string) ([]string, error) {
+	records, err := gpg.listKeys(ctx, gpgID)
+	if err != nil {
+		return nil, err
+	}
+
+	var keyIDs []string
+	for _, fields := range records {
+		if fields[0] == "pub" || fields[0] == "sub" {
+			keyIDs = append(keyIDs, fields[4])
+		}
+	}
+
+	return keyIDs, nil
+}
+
+func (gpg *gpg) UserIDs(ctx context.Context, keyID string) ([]string, error) {
+	records, err := gpg.listKeys(ctx, keyID)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	} else if err != nil {
+		// gpg fails when the key is not in the keyring.
+		return nil, nil
+	}
+
+	var userIDs []string
+	for _, fields := range records {
+		if fields[0] == "uid" {
+			userIDs = append(userIDs, fields[9])
+		}
+	}
+
+	return userIDs, nil
+}
+
+func (gpg *gpg) Sign(ctx context.Context, content []byte, keys []string) ([]byte, error) {
+	// gpg signs with every --local-user key and fails if one of them has no
+	// secret key, so the keys are tried one at a time.
+	err := errors.New("no signing key")
+	for _, key := range keys {
+		var signature []byte
+		signature, err = gpg.sign(ctx, content, key)
+		if err == nil || ctx.Err() != nil {
+			return signature, err
+		}
+	}
+
+	return nil, err
+}
+
+func (gpg *gpg) sign(ctx context.Context, content []byte, key string) ([]byte, error) {
 	gpgArgs := []string{
-		"--import",
+		"--detach-sign",
+		"--batch",
+		"--use-agent",
+		"--no-tty",
+		"--quiet",
+		"--yes",
+		"--local-user", key,
+		"--output", "-",
 	}
 
+	var stdout bytes.Buffer
 	var stderr bytes.Buffer
 
-	cmd := gpg.cmd(gpgArgs...)
+	cmd := gpg.cmd(ctx, gpgArgs...)
+	cmd.Stdin = bytes.NewReader(content)
+	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
-	stdin, err := cmd.StdinPipe()
+	if err := cmd.Run(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, fmt.Errorf("%w: %s", err, stderr.Bytes())
+	}
+
+	return stdout.Bytes(), nil
+}
+
+func (gpg *gpg) Verify(ctx context.Context, content []byte, signature []byte) ([]string, error) {
+	// gpg reads detached signatures from files only.
+	signatureFile, err := ioutil.TempFile("", "gopass-signature")
 	if err != nil {
-		return fmt.Errorf("could not get gpg's stdin: %w", err)
+		return nil, fmt.Errorf("could not create a temporary file: %w", err)
 	}
+	defer os.Remove(signatureFile.Name())
+	defer signatureFile.Close()
 
-	if err := cmd.Start(); err != nil {
-		return fmt.Errorf("could not start gpg: %w", err)
+	if _, err := signatureFile.Write(signature); err != nil {
+		return nil, fmt.Errorf("could not write the signature: %w", err)
 	}
 
-	if _, err := stdin.Write(content); err != nil {
-		return fmt.Errorf("could not write to gpg's stdin: %w", err)
+	gpgArgs := []string{
+		"--verify",
+		"--batch",
+		"--no-tty",
+		"--status-fd", "1",
+		"--", signatureFile.Name(), "-",
 	}
 
-	if err := stdin.Close(); err != nil {
-		return fmt.Errorf("could not close gpg's stdin: %w", err)
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	cmd := gpg.cmd(ctx, gpgArgs...)
+	cmd.Stdin = bytes.NewReader(content)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, fmt.Errorf("%w: %s", err, stderr.Bytes())
 	}
 
-	if err := cmd.Wait(); err != nil {
-		return fmt.Errorf("%w: %s", err, stderr.Bytes())
+	// [GNUPG:] VALIDSIG <fingerprint> ... <primary key fingerprint>
+	var fingerprints []string
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 || fields[0] != "[GNUPG:]" || fields[1] != "VALIDSIG" {
+			continue
+		}
+		fingerprints = append(fingerprints, fields[2])
+		if len(fields) > 11 {
+			fingerprints = append(fingerprints, fields[11])
+		}
 	}
 
-	return nil
+	return fingerprints, nil
 }
@@ -12,3 +12,21 @@ func TestImplementsGPG(t *testing.T) {
 		return gpg.New("", nil, false)
 	}
 }
+
+func TestImplementsContextGPG(t *testing.T) {
+	_ = func() store.ContextGPGBackend {
+		return gpg.New("", nil, false)
+	}
+}
+
+func TestImplementsRecipientsGPG(t *testing.T) {
+	_ = func() store.RecipientsGPGBackend {
+		return gpg.New("", nil, false)
+	}
+}
+
+func TestImplementsSigningGPG(t *testing.T) {
+	_ = func() store.SigningGPGBackend {
+		return gpg.New("", nil, false)
+	}
+}
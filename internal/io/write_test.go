@@ -0,0 +1,83 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package io_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	gopassio "github.com/aviau/gopass/internal/io"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "test.com.gpg")
+
+	if err := gopassio.WriteFileAtomic(filename, []byte("first"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := gopassio.WriteFileAtomic(filename, []byte("second"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := ioutil.ReadFile(filename)
+	assert.Nil(t, err)
+	assert.Equal(t, "second", string(content))
+
+	fi, err := os.Stat(filename)
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+
+	entries, err := ioutil.ReadDir(dir)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1, "no temporary file should be left behind")
+}
+
+func TestWriteFileAtomicMissingDirectory(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "dir", "test.com.gpg")
+
+	err := gopassio.WriteFileAtomic(filename, []byte("content"), 0600)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestCopyFileContents(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src")
+	dst := filepath.Join(dir, "dst")
+
+	if err := ioutil.WriteFile(src, []byte("content"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := gopassio.CopyFileContents(src, dst); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := ioutil.ReadFile(dst)
+	assert.Nil(t, err)
+	assert.Equal(t, "content", string(content))
+
+	fi, err := os.Stat(dst)
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+}
@@ -0,0 +1,133 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package pwgen
+
+import (
+	"bufio"
+	"crypto/rand"
+	_ "embed"
+	"errors"
+	"io"
+	"math"
+	"math/big"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// effLargeWordlist is the EFF long wordlist, as published on
+// https://www.eff.org/files/2016/07/18/eff_large_wordlist.txt.
+//
+//go:embed eff_large_wordlist.txt
+var effLargeWordlist string
+
+var (
+	effWordlist     []string
+	effWordlistOnce sync.Once
+)
+
+// ErrWordlistTooShort is returned when a word list has less than two
+// distinct words.
+var ErrWordlistTooShort = errors.New("the word list must have at least two distinct words")
+
+// EFFWordlist returns the 7776 words of the EFF long wordlist.
+func EFFWordlist() []string {
+	effWordlistOnce.Do(func() {
+		words, err := ParseWordlist(strings.NewReader(effLargeWordlist))
+		if err != nil {
+			panic(err)
+		}
+		effWordlist = words
+	})
+	return effWordlist
+}
+
+// ParseWordlist reads a word list with one word per line. As in diceware
+// lists, words may be preceded by their dice rolls. Blank lines and
+// duplicate words are ignored.
+func ParseWordlist(r io.Reader) ([]string, error) {
+	var words []string
+	seen := map[string]bool{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		word := fields[len(fields)-1]
+		if seen[word] {
+			continue
+		}
+		seen[word] = true
+		words = append(words, word)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(words) < 2 {
+		return nil, ErrWordlistTooShort
+	}
+
+	return words, nil
+}
+
+// PassphraseOptions configure Passphrase.
+type PassphraseOptions struct {
+	Words      int
+	Separator  string
+	Capitalize bool // Capitalize the first letter of every word.
+	Digit      bool // Add a random digit to the end of a random word.
+	Symbol     bool // Add a random symbol to the end of a random word.
+}
+
+// Passphrase returns a passphrase of words picked at random from wordlist,
+// along with its entropy in bits.
+func Passphrase(wordlist []string, opts PassphraseOptions) (string, float64) {
+	words := make([]string, opts.Words)
+	for i := range words {
+		words[i] = wordlist[randInt(len(wordlist))]
+		if opts.Capitalize {
+			r, size := utf8.DecodeRuneInString(words[i])
+			words[i] = string(unicode.ToUpper(r)) + words[i][size:]
+		}
+	}
+	entropy := float64(opts.Words) * math.Log2(float64(len(wordlist)))
+
+	if opts.Words > 0 && opts.Digit {
+		words[randInt(len(words))] += string(Num[randInt(len(Num))])
+		entropy += math.Log2(float64(len(Num) * len(words)))
+	}
+	if opts.Words > 0 && opts.Symbol {
+		words[randInt(len(words))] += string(Symbols[randInt(len(Symbols))])
+		entropy += math.Log2(float64(len(Symbols) * len(words)))
+	}
+
+	return strings.Join(words, opts.Separator), entropy
+}
+
+// randInt returns a random int in [0, n).
+func randInt(n int) int {
+	randomInt, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		panic(err)
+	}
+	return int(randomInt.Int64())
+}
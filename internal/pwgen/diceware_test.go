@@ -0,0 +1,80 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package pwgen_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aviau/gopass/internal/pwgen"
+)
+
+func TestEFFWordlist(t *testing.T) {
+	words := pwgen.EFFWordlist()
+
+	assert.Len(t, words, 7776)
+	assert.Equal(t, "abacus", words[0])
+	assert.Equal(t, "zoom", words[len(words)-1])
+}
+
+func TestParseWordlist(t *testing.T) {
+	words, err := pwgen.ParseWordlist(strings.NewReader("11111\tapple\n\n11112\tbanana\ncherry\napple\n"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"apple", "banana", "cherry"}, words)
+
+	_, err = pwgen.ParseWordlist(strings.NewReader("apple\napple\n"))
+	assert.Equal(t, pwgen.ErrWordlistTooShort, err)
+}
+
+func TestPassphrase(t *testing.T) {
+	wordlist := []string{"apple", "banana", "cherry", "date"}
+
+	passphrase, entropy := pwgen.Passphrase(wordlist, pwgen.PassphraseOptions{Words: 5, Separator: " "})
+
+	words := strings.Split(passphrase, " ")
+	assert.Len(t, words, 5)
+	for _, word := range words {
+		assert.Contains(t, wordlist, word)
+	}
+	assert.Equal(t, 10.0, entropy)
+}
+
+func TestPassphraseOptions(t *testing.T) {
+	wordlist := []string{"apple", "banana"}
+
+	passphrase, entropy := pwgen.Passphrase(wordlist, pwgen.PassphraseOptions{
+		Words:      4,
+		Separator:  " ",
+		Capitalize: true,
+		Digit:      true,
+		Symbol:     true,
+	})
+
+	words := strings.Split(passphrase, " ")
+	assert.Len(t, words, 4)
+	assert.True(t, strings.ContainsAny(passphrase, string(pwgen.Num)))
+	for _, word := range words {
+		assert.True(t, strings.HasPrefix(word, "Apple") || strings.HasPrefix(word, "Banana"), word)
+	}
+	expected := 4 + math.Log2(10*4) + math.Log2(float64(len(pwgen.Symbols)*4))
+	assert.InDelta(t, expected, entropy, 1e-9)
+}
@@ -0,0 +1,120 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package qrcode renders QR codes in the terminal and as PNG images.
+package qrcode
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"io"
+	"strings"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/qr"
+)
+
+// quietZone is the number of light modules around the code.
+const quietZone = 2
+
+// encode returns the QR code of content.
+func encode(content string) (barcode.Barcode, error) {
+	code, err := qr.Encode(content, qr.M, qr.Auto)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode the QR code: %w", err)
+	}
+	return code, nil
+}
+
+// Terminal writes content as a QR code made of Unicode half blocks, two
+// modules per character. Light modules are drawn, so that the code reads on
+// terminals with a dark background.
+func Terminal(w io.Writer, content string) error {
+	code, err := encode(content)
+	if err != nil {
+		return err
+	}
+
+	size := code.Bounds().Dx()
+
+	// isLight returns whether or not a module is light, the quiet zone
+	// included.
+	isLight := func(x, y int) bool {
+		x -= quietZone
+		y -= quietZone
+		if x < 0 || y < 0 || x >= size || y >= size {
+			return true
+		}
+		r, _, _, _ := code.At(x, y).RGBA()
+		return r != 0
+	}
+
+	var output strings.Builder
+	for y := 0; y < size+2*quietZone; y += 2 {
+		for x := 0; x < size+2*quietZone; x++ {
+			top := isLight(x, y)
+			// The bottom half of the last row is outside of the quiet
+			// zone when the height is odd.
+			bottom := y+1 < size+2*quietZone && isLight(x, y+1)
+			switch {
+			case top && bottom:
+				output.WriteString("█")
+			case top:
+				output.WriteString("▀")
+			case bottom:
+				output.WriteString("▄")
+			default:
+				output.WriteString(" ")
+			}
+		}
+		output.WriteString("\n")
+	}
+
+	_, err = io.WriteString(w, output.String())
+	return err
+}
+
+// PNG writes content as a QR code in a PNG image of about size pixels wide.
+func PNG(w io.Writer, content string, size int) error {
+	code, err := encode(content)
+	if err != nil {
+		return err
+	}
+
+	// Scale to a multiple of the number of modules to keep them sharp.
+	modules := code.Bounds().Dx()
+	scale := size / (modules + 2*quietZone)
+	if scale < 1 {
+		scale = 1
+	}
+
+	scaled, err := barcode.Scale(code, modules*scale, modules*scale)
+	if err != nil {
+		return fmt.Errorf("could not scale the QR code: %w", err)
+	}
+
+	// Add the quiet zone.
+	width := (modules + 2*quietZone) * scale
+	img := image.NewGray(image.Rect(0, 0, width, width))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+	offset := image.Pt(quietZone*scale, quietZone*scale)
+	draw.Draw(img, scaled.Bounds().Add(offset), scaled, image.Point{}, draw.Src)
+
+	return png.Encode(w, img)
+}
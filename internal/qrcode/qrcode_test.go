@@ -0,0 +1,56 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package qrcode_test
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/aviau/gopass/internal/qrcode"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTerminal(t *testing.T) {
+	var output bytes.Buffer
+
+	err := qrcode.Terminal(&output, "hello")
+	assert.Nil(t, err)
+
+	// A version 1 code has 21 modules, plus the quiet zone on each side.
+	lines := strings.Split(strings.TrimSuffix(output.String(), "\n"), "\n")
+	assert.Len(t, lines, 13)
+	for _, line := range lines {
+		assert.Equal(t, 25, utf8.RuneCountInString(line))
+	}
+	assert.Equal(t, strings.Repeat("█", 25), lines[0], "the quiet zone should be light")
+}
+
+func TestPNG(t *testing.T) {
+	var output bytes.Buffer
+
+	err := qrcode.PNG(&output, "hello", 512)
+	assert.Nil(t, err)
+
+	img, err := png.Decode(&output)
+	assert.Nil(t, err)
+	assert.Equal(t, 500, img.Bounds().Dx(), "the size should be a multiple of the 25 modules")
+	assert.Equal(t, img.Bounds().Dx(), img.Bounds().Dy())
+}
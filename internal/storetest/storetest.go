@@ -76,6 +76,11 @@ func NewPasswordStoreTest(t *testing.T) *PasswordStoreTest {
 		t.Fatal(err)
 	}
 
+	// Open the store again as commands do, which finds that it has no git
+	// repository.
+	passwordStore = store.NewPasswordStore(storePath)
+	passwordStore.GPGBackend = gpgBackend
+
 	passwordStoreTest := PasswordStoreTest{
 		PasswordStore: passwordStore,
 		storePath:     storePath,
@@ -0,0 +1,206 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package otp
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// MigrationScheme is the scheme of the URIs that Google Authenticator
+// exports accounts with.
+const MigrationScheme = "otpauth-migration"
+
+// ErrInvalidMigration is returned when a migration payload can't be
+// decoded.
+var ErrInvalidMigration = errors.New("invalid otpauth-migration payload")
+
+// Values of the enums of the migration payload.
+var (
+	migrationAlgorithms = map[uint64]string{1: "SHA1", 2: "SHA256", 3: "SHA512", 4: "MD5"}
+	migrationDigits     = map[uint64]string{1: "6", 2: "8"}
+	migrationTypes      = map[uint64]string{1: TypeHOTP, 2: TypeTOTP}
+)
+
+// ParseMigrationURL decodes the keys of an
+// otpauth-migration://offline?data=... URI.
+//
+// The data is a base64 encoded protocol buffer, of which only the fields
+// that describe the keys are read:
+//
+//	message MigrationPayload {
+//	  message OtpParameters {
+//	    bytes secret = 1;
+//	    string name = 2;
+//	    string issuer = 3;
+//	    Algorithm algorithm = 4;
+//	    DigitCount digits = 5;
+//	    OtpType type = 6;
+//	    int64 counter = 7;
+//	  }
+//	  repeated OtpParameters otp_parameters = 1;
+//	}
+func ParseMigrationURL(rawURL string) ([]*Key, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse otpauth-migration URI: %w", err)
+	}
+	if u.Scheme != MigrationScheme {
+		return nil, fmt.Errorf("could not parse otpauth-migration URI: unknown scheme \"%s\"", u.Scheme)
+	}
+
+	// The data is not always escaped, so its pluses may have been decoded
+	// as spaces.
+	data := strings.ReplaceAll(u.Query().Get("data"), " ", "+")
+	payload, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		if payload, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(data, "=")); err != nil {
+			return nil, ErrInvalidMigration
+		}
+	}
+
+	var keys []*Key
+	err = readProtobuf(payload, func(field int, value []byte, _ uint64) error {
+		if field != 1 || value == nil {
+			return nil
+		}
+		key, err := parseMigrationKey(value)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// parseMigrationKey decodes an OtpParameters message.
+func parseMigrationKey(message []byte) (*Key, error) {
+	var secret []byte
+	var name, issuer string
+	var algorithm, digits, otpType, counter uint64
+
+	err := readProtobuf(message, func(field int, value []byte, number uint64) error {
+		switch field {
+		case 1:
+			secret = value
+		case 2:
+			name = string(value)
+		case 3:
+			issuer = string(value)
+		case 4:
+			algorithm = number
+		case 5:
+			digits = number
+		case 6:
+			otpType = number
+		case 7:
+			counter = number
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("secret", base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(secret))
+	if issuer != "" {
+		query.Set("issuer", issuer)
+	}
+	if value, ok := migrationAlgorithms[algorithm]; ok {
+		query.Set("algorithm", value)
+	}
+	if value, ok := migrationDigits[digits]; ok {
+		query.Set("digits", value)
+	}
+	keyType, ok := migrationTypes[otpType]
+	if !ok {
+		keyType = TypeTOTP
+	}
+	if keyType == TypeHOTP {
+		query.Set("counter", strconv.FormatUint(counter, 10))
+	}
+
+	u := url.URL{
+		Scheme:   Scheme,
+		Host:     keyType,
+		Path:     "/" + name,
+		RawQuery: query.Encode(),
+	}
+	return ParseURL(u.String())
+}
+
+// readProtobuf calls fn with the number and the value of every field of a
+// protocol buffer message. The value of length-delimited fields is in
+// bytes, and the value of varint fields in number.
+func readProtobuf(message []byte, fn func(field int, bytes []byte, number uint64) error) error {
+	for len(message) > 0 {
+		tag, n := binary.Uvarint(message)
+		if n <= 0 {
+			return ErrInvalidMigration
+		}
+		message = message[n:]
+
+		field := int(tag >> 3)
+		switch tag & 7 {
+		case 0: // varint
+			number, n := binary.Uvarint(message)
+			if n <= 0 {
+				return ErrInvalidMigration
+			}
+			message = message[n:]
+			if err := fn(field, nil, number); err != nil {
+				return err
+			}
+		case 1: // 64-bit
+			if len(message) < 8 {
+				return ErrInvalidMigration
+			}
+			message = message[8:]
+		case 2: // length-delimited
+			length, n := binary.Uvarint(message)
+			if n <= 0 || uint64(len(message)-n) < length {
+				return ErrInvalidMigration
+			}
+			value := message[n : n+int(length)]
+			message = message[n+int(length):]
+			if err := fn(field, value, 0); err != nil {
+				return err
+			}
+		case 5: // 32-bit
+			if len(message) < 4 {
+				return ErrInvalidMigration
+			}
+			message = message[4:]
+		default:
+			return ErrInvalidMigration
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package otp_test
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"net/url"
+	"testing"
+
+	"github.com/aviau/gopass/pkg/otp"
+	"github.com/stretchr/testify/assert"
+)
+
+// protobufVarint encodes a varint field.
+func protobufVarint(field int, value uint64) []byte {
+	b := make([]byte, 2*binary.MaxVarintLen64)
+	n := binary.PutUvarint(b, uint64(field<<3))
+	n += binary.PutUvarint(b[n:], value)
+	return b[:n]
+}
+
+// protobufBytes encodes a length-delimited field.
+func protobufBytes(field int, value []byte) []byte {
+	b := make([]byte, 2*binary.MaxVarintLen64)
+	n := binary.PutUvarint(b, uint64(field<<3|2))
+	n += binary.PutUvarint(b[n:], uint64(len(value)))
+	return append(b[:n], value...)
+}
+
+func concat(parts ...[]byte) []byte {
+	var b []byte
+	for _, part := range parts {
+		b = append(b, part...)
+	}
+	return b
+}
+
+// migrationURI returns an otpauth-migration URI for the payload.
+func migrationURI(payload []byte) string {
+	return "otpauth-migration://offline?data=" + url.QueryEscape(base64.StdEncoding.EncodeToString(payload))
+}
+
+func TestParseMigrationURL(t *testing.T) {
+	payload := concat(
+		protobufBytes(1, concat(
+			protobufBytes(1, []byte("Hello!\xde\xad\xbe\xef")),
+			protobufBytes(2, []byte("Example:alice@example.com")),
+			protobufBytes(3, []byte("Example")),
+			protobufVarint(4, 2),
+			protobufVarint(5, 2),
+			protobufVarint(6, 2),
+		)),
+		protobufBytes(1, concat(
+			protobufBytes(1, []byte("12345678901234567890")),
+			protobufBytes(2, []byte("vpn")),
+			protobufVarint(6, 1),
+			protobufVarint(7, 42),
+		)),
+		protobufVarint(2, 1),
+		protobufVarint(3, 1),
+	)
+
+	keys, err := otp.ParseMigrationURL(migrationURI(payload))
+
+	assert.Nil(t, err)
+	if assert.Len(t, keys, 2) {
+		assert.Equal(t, "otpauth://totp/Example:alice@example.com?algorithm=SHA256&digits=8&issuer=Example&secret=JBSWY3DPEHPK3PXP", keys[0].URL())
+		assert.Equal(t, "Example", keys[0].Issuer)
+		assert.Equal(t, "alice@example.com", keys[0].Account)
+		assert.Equal(t, 8, keys[0].Digits.Length())
+
+		assert.Equal(t, otp.TypeHOTP, keys[1].Type)
+		assert.Equal(t, "vpn", keys[1].Account)
+		assert.Equal(t, uint64(42), keys[1].Counter)
+		assert.Equal(t, "SHA1", keys[1].Algorithm.String())
+	}
+}
+
+func TestParseMigrationURLUnescaped(t *testing.T) {
+	// "+" and "/" in the data must survive not being escaped.
+	payload := protobufBytes(1, concat(
+		protobufBytes(1, []byte{0xfb, 0xef, 0xff, 0xfb, 0xef, 0xff}),
+		protobufBytes(2, []byte("test")),
+	))
+	uri := "otpauth-migration://offline?data=" + base64.StdEncoding.EncodeToString(payload)
+
+	keys, err := otp.ParseMigrationURL(uri)
+
+	assert.Nil(t, err)
+	if assert.Len(t, keys, 1) {
+		assert.Equal(t, "7PX7767P74", keys[0].Secret)
+	}
+}
+
+func TestParseMigrationURLInvalid(t *testing.T) {
+	for _, uri := range []string{
+		"otpauth-migration://offline?data=not-base64!",
+		migrationURI([]byte{0x0a, 0x05, 0x01}),
+		migrationURI([]byte{0x0a}),
+	} {
+		_, err := otp.ParseMigrationURL(uri)
+		assert.Equal(t, otp.ErrInvalidMigration, err, uri)
+	}
+
+	_, err := otp.ParseMigrationURL("otpauth://totp/test?secret=JBSWY3DPEHPK3PXP")
+	assert.EqualError(t, err, "could not parse otpauth-migration URI: unknown scheme \"otpauth\"")
+}
@@ -0,0 +1,287 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package otp generates the one-time passwords of the otpauth:// URIs used
+// by authenticator apps:
+//
+//	otpauth://totp/Example:alice@example.com?secret=JBSWY3DPEHPK3PXP&issuer=Example&digits=8&algorithm=SHA256&period=60
+//
+// Every parameter of the URI is honored. Counter-based "hotp" keys are
+// supported as well: their code uses the "counter" parameter, which must
+// then be incremented with SetCounter and saved back into the entry.
+//
+// An entry holds its key either as a "totp", "otp" or "2fa" field, whose
+// value is a URI or a bare base32 secret, or as a line of its own starting
+// with "otpauth://", which may be the first one as with pass-otp.
+package otp
+
+import (
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aviau/gopass/pkg/secret"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/hotp"
+	"github.com/pquerna/otp/totp"
+)
+
+// ErrNotFound is returned when an entry does not hold an otp key.
+var ErrNotFound = errors.New("could not find an otp key in the password")
+
+// Scheme is the scheme of otp URIs.
+const Scheme = "otpauth"
+
+// Types of keys.
+const (
+	TypeTOTP = "totp"
+	TypeHOTP = "hotp"
+)
+
+// fieldKeys are the keys of the fields that can hold an otp key.
+var fieldKeys = []string{"totp", "otp", "2fa"}
+
+// DefaultFieldKey is the key of the field that SaveTo adds to entries that
+// do not hold an otp key yet.
+const DefaultFieldKey = "2fa"
+
+// Key is a parsed otp URI.
+type Key struct {
+	Type      string
+	Issuer    string
+	Account   string
+	Secret    string
+	Algorithm otp.Algorithm
+	Digits    otp.Digits
+	Period    time.Duration // Only for TOTP keys.
+	Counter   uint64        // Only for HOTP keys.
+
+	url *url.URL
+}
+
+// Parse parses an otpauth:// URI or a bare base32 secret, which is then
+// used with the default parameters.
+func Parse(s string) (*Key, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, Scheme+"://") {
+		return ParseURL(s)
+	}
+	return NewTOTP("", s)
+}
+
+// NewTOTP returns a TOTP key with the default parameters for a base32
+// secret, which may be in lower case and contain spaces.
+func NewTOTP(account, base32Secret string) (*Key, error) {
+	base32Secret = strings.ToUpper(strings.ReplaceAll(base32Secret, " ", ""))
+	u := url.URL{
+		Scheme:   Scheme,
+		Host:     TypeTOTP,
+		Path:     "/" + account,
+		RawQuery: "secret=" + url.QueryEscape(base32Secret),
+	}
+	return ParseURL(u.String())
+}
+
+// ParseURL parses an otpauth:// URI.
+func ParseURL(rawURL string) (*Key, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse otp URI: %w", err)
+	}
+	if u.Scheme != Scheme {
+		return nil, fmt.Errorf("could not parse otp URI: unknown scheme \"%s\"", u.Scheme)
+	}
+
+	key := &Key{
+		Type: strings.ToLower(u.Host),
+		url:  u,
+	}
+	if key.Type != TypeTOTP && key.Type != TypeHOTP {
+		return nil, fmt.Errorf("unsupported otp type \"%s\"", u.Host)
+	}
+
+	query := u.Query()
+
+	// The label is "issuer:account" or "account".
+	label := strings.TrimPrefix(u.Path, "/")
+	if i := strings.Index(label, ":"); i >= 0 {
+		key.Issuer = strings.TrimSpace(label[:i])
+		label = label[i+1:]
+	}
+	key.Account = strings.TrimSpace(label)
+	if issuer := query.Get("issuer"); issuer != "" {
+		key.Issuer = issuer
+	}
+
+	key.Secret = strings.ToUpper(strings.ReplaceAll(query.Get("secret"), " ", ""))
+	if key.Secret == "" {
+		return nil, errors.New("the otp key has no secret")
+	}
+	if _, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.TrimRight(key.Secret, "=")); err != nil {
+		return nil, errors.New("the otp secret is not valid base32")
+	}
+
+	switch algorithm := strings.ToUpper(query.Get("algorithm")); algorithm {
+	case "", "SHA1":
+		key.Algorithm = otp.AlgorithmSHA1
+	case "SHA256":
+		key.Algorithm = otp.AlgorithmSHA256
+	case "SHA512":
+		key.Algorithm = otp.AlgorithmSHA512
+	case "MD5":
+		key.Algorithm = otp.AlgorithmMD5
+	default:
+		return nil, fmt.Errorf("unsupported otp algorithm \"%s\"", query.Get("algorithm"))
+	}
+
+	key.Digits = otp.DigitsSix
+	if digits := query.Get("digits"); digits != "" {
+		n, err := strconv.Atoi(digits)
+		if err != nil || n < 6 || n > 8 {
+			return nil, fmt.Errorf("unsupported number of otp digits \"%s\"", digits)
+		}
+		key.Digits = otp.Digits(n)
+	}
+
+	if key.Type == TypeHOTP {
+		counter := query.Get("counter")
+		if counter == "" {
+			return nil, errors.New("the hotp key has no counter")
+		}
+		if key.Counter, err = strconv.ParseUint(counter, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid otp counter \"%s\"", counter)
+		}
+		return key, nil
+	}
+
+	key.Period = 30 * time.Second
+	if period := query.Get("period"); period != "" {
+		n, err := strconv.Atoi(period)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid otp period \"%s\"", period)
+		}
+		key.Period = time.Duration(n) * time.Second
+	}
+
+	return key, nil
+}
+
+// FromSecret finds and parses the otp key of an entry.
+func FromSecret(s *secret.Secret) (*Key, error) {
+	value, _, _, ok := find(s)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return Parse(value)
+}
+
+// find returns the otp key of an entry, whether valid or not, and either
+// the key of the field or the number of the line that holds it.
+func find(s *secret.Secret) (string, string, int, bool) {
+	for _, fieldKey := range fieldKeys {
+		if value, ok := s.Get(fieldKey); ok {
+			return value, fieldKey, 0, true
+		}
+	}
+
+	for n := 1; n <= s.LineCount(); n++ {
+		text, _ := s.Line(n)
+		if strings.HasPrefix(strings.TrimSpace(text), Scheme+"://") {
+			return text, "", n, true
+		}
+	}
+
+	return "", "", 0, false
+}
+
+// URL returns the URI of the key.
+func (key *Key) URL() string {
+	return key.url.String()
+}
+
+// SaveTo writes the URI of the key in place of the otp key of the entry, or
+// in a new DefaultFieldKey field.
+func (key *Key) SaveTo(s *secret.Secret) {
+	_, fieldKey, n, ok := find(s)
+	if !ok {
+		s.Set(DefaultFieldKey, key.URL())
+	} else if n != 0 {
+		s.SetLine(n, key.URL())
+	} else {
+		s.Set(fieldKey, key.URL())
+	}
+}
+
+// SetCounter sets the counter of an HOTP key, keeping the other parameters
+// of its URI as they were.
+func (key *Key) SetCounter(counter uint64) {
+	key.Counter = counter
+
+	parameter := "counter=" + strconv.FormatUint(counter, 10)
+	var parameters []string
+	if key.url.RawQuery != "" {
+		parameters = strings.Split(key.url.RawQuery, "&")
+	}
+	for i, p := range parameters {
+		if strings.HasPrefix(p, "counter=") {
+			parameters[i] = parameter
+			parameter = ""
+		}
+	}
+	if parameter != "" {
+		parameters = append(parameters, parameter)
+	}
+	key.url.RawQuery = strings.Join(parameters, "&")
+}
+
+// Code returns the code of a TOTP key at t, or the code of an HOTP key for
+// its counter.
+func (key *Key) Code(t time.Time) (string, error) {
+	var code string
+	var err error
+	if key.Type == TypeHOTP {
+		code, err = hotp.GenerateCodeCustom(key.Secret, key.Counter, hotp.ValidateOpts{
+			Digits:    key.Digits,
+			Algorithm: key.Algorithm,
+		})
+	} else {
+		code, err = totp.GenerateCodeCustom(key.Secret, t.UTC(), totp.ValidateOpts{
+			Period:    uint(key.Period / time.Second),
+			Digits:    key.Digits,
+			Algorithm: key.Algorithm,
+		})
+	}
+	if err != nil {
+		return "", fmt.Errorf("could not generate otp code: %w", err)
+	}
+	return code, nil
+}
+
+// Remaining returns how long the code of a TOTP key at t remains valid. The
+// code of an HOTP key does not expire, so it is always 0.
+func (key *Key) Remaining(t time.Time) time.Duration {
+	if key.Type == TypeHOTP {
+		return 0
+	}
+	period := int64(key.Period / time.Second)
+	next := (t.Unix()/period + 1) * period
+	return time.Unix(next, 0).Sub(t)
+}
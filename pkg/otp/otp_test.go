@@ -0,0 +1,200 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package otp_test
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/aviau/gopass/pkg/otp"
+	"github.com/aviau/gopass/pkg/secret"
+	"github.com/stretchr/testify/assert"
+)
+
+// rfc6238URI returns the URI of an RFC 6238 test vector key.
+func rfc6238URI(seed, algorithm string) string {
+	return "otpauth://totp/Example:alice@example.com?secret=" +
+		url.QueryEscape(base32.StdEncoding.EncodeToString([]byte(seed))) +
+		"&digits=8&algorithm=" + algorithm
+}
+
+func TestCodeRFC6238(t *testing.T) {
+	keys := map[string]string{
+		"SHA1":   rfc6238URI("12345678901234567890", "SHA1"),
+		"SHA256": rfc6238URI("12345678901234567890123456789012", "SHA256"),
+		"SHA512": rfc6238URI("1234567890123456789012345678901234567890123456789012345678901234", "SHA512"),
+	}
+
+	vectors := []struct {
+		unix      int64
+		algorithm string
+		code      string
+	}{
+		{59, "SHA1", "94287082"},
+		{59, "SHA256", "46119246"},
+		{59, "SHA512", "90693936"},
+		{1111111109, "SHA1", "07081804"},
+		{1111111109, "SHA256", "68084774"},
+		{1111111109, "SHA512", "25091201"},
+		{20000000000, "SHA256", "77737706"},
+	}
+
+	for _, vector := range vectors {
+		key, err := otp.ParseURL(keys[vector.algorithm])
+		assert.Nil(t, err)
+
+		code, err := key.Code(time.Unix(vector.unix, 0))
+		assert.Nil(t, err)
+		assert.Equal(t, vector.code, code, "%s at %d", vector.algorithm, vector.unix)
+	}
+}
+
+func TestParseURL(t *testing.T) {
+	key, err := otp.ParseURL("otpauth://totp/Example:alice@example.com?secret=JBSWY3DPEHPK3PXP&digits=7&period=60&algorithm=sha512")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "totp", key.Type)
+	assert.Equal(t, "Example", key.Issuer)
+	assert.Equal(t, "alice@example.com", key.Account)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", key.Secret)
+	assert.Equal(t, "SHA512", key.Algorithm.String())
+	assert.Equal(t, 7, key.Digits.Length())
+	assert.Equal(t, time.Minute, key.Period)
+}
+
+func TestParseURLInvalid(t *testing.T) {
+	invalid := map[string]string{
+		"https://example.com":                                        "unknown scheme",
+		"otpauth://totp/test":                                        "no secret",
+		"otpauth://totp/test?secret=not-base32!":                     "not valid base32",
+		"otpauth://totp/test?secret=JBSWY3DPEHPK3PXP&algorithm=SHA3": "unsupported otp algorithm",
+		"otpauth://totp/test?secret=JBSWY3DPEHPK3PXP&digits=12":      "unsupported number of otp digits",
+		"otpauth://totp/test?secret=JBSWY3DPEHPK3PXP&period=0":       "invalid otp period",
+	}
+
+	for uri, message := range invalid {
+		_, err := otp.ParseURL(uri)
+		if assert.Error(t, err, uri) {
+			assert.Contains(t, err.Error(), message)
+		}
+	}
+}
+
+func TestParseBareSecret(t *testing.T) {
+	key, err := otp.Parse("jbsw y3dp ehpk 3pxp")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", key.Secret)
+	assert.Equal(t, 6, key.Digits.Length())
+	assert.Equal(t, 30*time.Second, key.Period)
+}
+
+func TestFromSecret(t *testing.T) {
+	entries := []string{
+		"hello\n2fa: JBSWY3DPEHPK3PXP\n",
+		"hello\ntotp: otpauth://totp/test?secret=JBSWY3DPEHPK3PXP\n",
+		"hello\nusername: alice\notpauth://totp/test?secret=JBSWY3DPEHPK3PXP\n",
+		"otpauth://totp/test?secret=JBSWY3DPEHPK3PXP\n",
+	}
+
+	for _, entry := range entries {
+		key, err := otp.FromSecret(secret.Parse(entry))
+		if assert.Nil(t, err, entry) {
+			assert.Equal(t, "JBSWY3DPEHPK3PXP", key.Secret)
+		}
+	}
+
+	_, err := otp.FromSecret(secret.Parse("hello\nusername: alice\n"))
+	assert.Equal(t, otp.ErrNotFound, err)
+}
+
+func TestRemaining(t *testing.T) {
+	key, err := otp.Parse("JBSWY3DPEHPK3PXP")
+	assert.Nil(t, err)
+
+	assert.Equal(t, 30*time.Second, key.Remaining(time.Unix(60, 0)))
+	assert.Equal(t, 1500*time.Millisecond, key.Remaining(time.Unix(88, 500000000)))
+}
+
+func TestCodeRFC4226(t *testing.T) {
+	uri := "otpauth://hotp/test?secret=" + base32.StdEncoding.EncodeToString([]byte("12345678901234567890")) + "&counter=0"
+
+	key, err := otp.ParseURL(uri)
+	assert.Nil(t, err)
+	assert.Equal(t, otp.TypeHOTP, key.Type)
+
+	for counter, expected := range []string{"755224", "287082", "359152", "969429", "338314"} {
+		key.SetCounter(uint64(counter))
+
+		code, err := key.Code(time.Time{})
+		assert.Nil(t, err)
+		assert.Equal(t, expected, code, "counter %d", counter)
+	}
+}
+
+func TestSetCounter(t *testing.T) {
+	key, err := otp.ParseURL("otpauth://hotp/test?secret=JBSWY3DPEHPK3PXP&counter=41&issuer=Example")
+	assert.Nil(t, err)
+
+	key.SetCounter(42)
+
+	assert.Equal(t, uint64(42), key.Counter)
+	assert.Equal(t, "otpauth://hotp/test?secret=JBSWY3DPEHPK3PXP&counter=42&issuer=Example", key.URL())
+
+	_, err = otp.ParseURL("otpauth://hotp/test?secret=JBSWY3DPEHPK3PXP")
+	assert.EqualError(t, err, "the hotp key has no counter")
+}
+
+func TestSaveTo(t *testing.T) {
+	for entry, expected := range map[string]string{
+		"hello\n2fa: otpauth://hotp/test?secret=JBSWY3DPEHPK3PXP&counter=1\nnotes\n": "hello\n2fa: otpauth://hotp/test?secret=JBSWY3DPEHPK3PXP&counter=2\nnotes\n",
+		"hello\notpauth://hotp/test?secret=JBSWY3DPEHPK3PXP&counter=1\nnotes\n":      "hello\notpauth://hotp/test?secret=JBSWY3DPEHPK3PXP&counter=2\nnotes\n",
+		"otpauth://hotp/test?secret=JBSWY3DPEHPK3PXP&counter=1":                      "otpauth://hotp/test?secret=JBSWY3DPEHPK3PXP&counter=2",
+	} {
+		s := secret.Parse(entry)
+
+		key, err := otp.FromSecret(s)
+		assert.Nil(t, err)
+
+		key.SetCounter(key.Counter + 1)
+		key.SaveTo(s)
+
+		assert.Equal(t, expected, s.String())
+	}
+}
+
+func TestNewTOTP(t *testing.T) {
+	key, err := otp.NewTOTP("alice@example.com", "jbsw y3dp ehpk 3pxp")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "otpauth://totp/alice@example.com?secret=JBSWY3DPEHPK3PXP", key.URL())
+	assert.Equal(t, "alice@example.com", key.Account)
+}
+
+func TestSaveToAddsField(t *testing.T) {
+	s := secret.Parse("hello\nusername: alice\nnotes\n")
+
+	key, err := otp.Parse("JBSWY3DPEHPK3PXP")
+	assert.Nil(t, err)
+
+	key.SaveTo(s)
+
+	assert.Equal(t, "hello\nusername: alice\n2fa: otpauth://totp/?secret=JBSWY3DPEHPK3PXP\nnotes\n", s.String())
+}
@@ -0,0 +1,267 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package secret parses the entries of a password store.
+//
+// The first line of an entry is the password. Every other line of the form
+// "key: value" is a field, and the remaining lines are the body:
+//
+//	correct horse battery staple
+//	username: alice
+//	url: https://example.com
+//	Security questions are in the safe.
+//
+// A line is a field if it does not start with a space and its first colon is
+// followed by a space or ends the line, so that URLs are not mistaken for
+// fields. Keys are compared without regard to case. Entries are kept as they
+// were parsed, so that String returns the original content byte for byte
+// until the entry is modified.
+package secret
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Field is a "key: value" line of an entry.
+type Field struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Secret is a parsed entry.
+type Secret struct {
+	lines           []line
+	trailingNewline bool
+}
+
+// line is a line of an entry after the password. It is a field if key is not
+// empty.
+type line struct {
+	text      string
+	key       string
+	separator string // What follows the colon up to the value.
+	value     string
+}
+
+// Parse parses the decrypted content of an entry.
+func Parse(content string) *Secret {
+	s := &Secret{}
+
+	if strings.HasSuffix(content, "\n") {
+		s.trailingNewline = true
+		content = strings.TrimSuffix(content, "\n")
+	}
+
+	for _, text := range strings.Split(content, "\n") {
+		s.lines = append(s.lines, parseLine(text))
+	}
+
+	// The first line is always the password.
+	s.lines[0] = line{text: s.lines[0].text}
+
+	return s
+}
+
+// parseLine parses a line after the password.
+func parseLine(text string) line {
+	i := strings.Index(text, ":")
+	if i <= 0 || strings.TrimSpace(text[:1]) == "" {
+		return line{text: text}
+	}
+
+	key := text[:i]
+	rest := text[i+1:]
+	value := strings.TrimLeft(rest, " \t")
+	if rest != "" && len(value) == len(rest) && !isCompactField(key, value) {
+		// The colon is followed by something else than a space, such as in
+		// "https://".
+		return line{text: text}
+	}
+
+	return line{
+		text:      text,
+		key:       key,
+		separator: rest[:len(rest)-len(value)],
+		value:     value,
+	}
+}
+
+// isCompactField returns whether or not a line whose colon is not followed by
+// a space, such as "username:alice", is a field. URLs such as "https://" are
+// not.
+func isCompactField(key, value string) bool {
+	return !strings.ContainsAny(key, " \t/") && !strings.HasPrefix(value, "/")
+}
+
+// Password returns the first line of the entry.
+func (s *Secret) Password() string {
+	return s.lines[0].text
+}
+
+// SetPassword replaces the first line of the entry.
+func (s *Secret) SetPassword(password string) {
+	s.lines[0] = line{text: password}
+}
+
+// Line returns a line of the entry, the first one being 1, and whether or
+// not it exists.
+func (s *Secret) Line(n int) (string, bool) {
+	if n < 1 || n > len(s.lines) {
+		return "", false
+	}
+	return s.lines[n-1].text, true
+}
+
+// SetLine replaces a line of the entry, the first one being 1, and returns
+// whether or not it exists.
+func (s *Secret) SetLine(n int, text string) bool {
+	if n < 1 || n > len(s.lines) {
+		return false
+	}
+	if n == 1 {
+		s.SetPassword(text)
+	} else {
+		s.lines[n-1] = parseLine(text)
+	}
+	return true
+}
+
+// LineCount returns the number of lines of the entry.
+func (s *Secret) LineCount() int {
+	return len(s.lines)
+}
+
+// Fields returns the fields of the entry, in order.
+func (s *Secret) Fields() []Field {
+	var fields []Field
+	for _, l := range s.lines[1:] {
+		if l.key != "" {
+			fields = append(fields, Field{Key: l.key, Value: l.value})
+		}
+	}
+	return fields
+}
+
+// Body returns the lines of the entry that are neither the password nor
+// fields.
+func (s *Secret) Body() string {
+	var body []string
+	for _, l := range s.lines[1:] {
+		if l.key == "" {
+			body = append(body, l.text)
+		}
+	}
+	return strings.Join(body, "\n")
+}
+
+// Get returns the value of the first field with the given key.
+func (s *Secret) Get(key string) (string, bool) {
+	if i := s.index(key); i > 0 {
+		return s.lines[i].value, true
+	}
+	return "", false
+}
+
+// Set replaces the value of the first field with the given key, or adds the
+// field after the last one. The value must not contain newlines.
+func (s *Secret) Set(key, value string) {
+	if i := s.index(key); i > 0 {
+		l := &s.lines[i]
+		if l.separator == "" {
+			l.separator = " "
+		}
+		l.value = value
+		l.text = l.key + ":" + l.separator + value
+		return
+	}
+
+	// Add the field after the last one, or after the password.
+	last := 0
+	for i, l := range s.lines {
+		if l.key != "" {
+			last = i
+		}
+	}
+
+	field := line{text: key + ": " + value, key: key, separator: " ", value: value}
+
+	s.lines = append(s.lines, line{})
+	copy(s.lines[last+2:], s.lines[last+1:])
+	s.lines[last+1] = field
+}
+
+// Delete removes the fields with the given key and returns whether or not
+// there were any.
+func (s *Secret) Delete(key string) bool {
+	var lines []line
+	for i, l := range s.lines {
+		if i > 0 && l.key != "" && strings.EqualFold(l.key, key) {
+			continue
+		}
+		lines = append(lines, l)
+	}
+
+	deleted := len(lines) != len(s.lines)
+	s.lines = lines
+
+	return deleted
+}
+
+// index returns the index of the first field with the given key, or -1.
+func (s *Secret) index(key string) int {
+	for i, l := range s.lines {
+		if i > 0 && l.key != "" && strings.EqualFold(l.key, key) {
+			return i
+		}
+	}
+	return -1
+}
+
+// String returns the content of the entry.
+func (s *Secret) String() string {
+	var content strings.Builder
+	for i, l := range s.lines {
+		if i > 0 {
+			content.WriteString("\n")
+		}
+		content.WriteString(l.text)
+	}
+	if s.trailingNewline {
+		content.WriteString("\n")
+	}
+	return content.String()
+}
+
+// MarshalJSON encodes the entry as an object with its password, its fields
+// in order and its body.
+func (s *Secret) MarshalJSON() ([]byte, error) {
+	fields := s.Fields()
+	if fields == nil {
+		fields = []Field{}
+	}
+
+	return json.Marshal(struct {
+		Password string  `json:"password"`
+		Fields   []Field `json:"fields"`
+		Body     string  `json:"body"`
+	}{
+		Password: s.Password(),
+		Fields:   fields,
+		Body:     s.Body(),
+	})
+}
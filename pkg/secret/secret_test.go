@@ -0,0 +1,191 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package secret_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aviau/gopass/pkg/secret"
+	"github.com/stretchr/testify/assert"
+)
+
+const entry = `correct horse battery staple
+username: alice
+url:  https://example.com
+https://example.com/login
+Notes:
+
+The security questions are in the safe.
+`
+
+func TestParse(t *testing.T) {
+	s := secret.Parse(entry)
+
+	assert.Equal(t, "correct horse battery staple", s.Password())
+	assert.Equal(
+		t,
+		[]secret.Field{
+			{Key: "username", Value: "alice"},
+			{Key: "url", Value: "https://example.com"},
+			{Key: "Notes", Value: ""},
+		},
+		s.Fields(),
+	)
+	assert.Equal(t, "https://example.com/login\n\nThe security questions are in the safe.", s.Body())
+}
+
+func TestParseRoundTrips(t *testing.T) {
+	for _, content := range []string{
+		"",
+		"\n",
+		"password",
+		"password\n",
+		"password\n\n\n",
+		" password: with a colon\n",
+		entry,
+		"password\r\nkey: value\r\n",
+	} {
+		assert.Equal(t, content, secret.Parse(content).String())
+	}
+}
+
+func TestParseFieldsWithoutSpace(t *testing.T) {
+	s := secret.Parse("password\nusername:alice\n2fa:JBSWY3DPEHPK3PXP\nhttps://example.com\nsee /etc/hosts:2\n")
+
+	assert.Equal(
+		t,
+		[]secret.Field{
+			{Key: "username", Value: "alice"},
+			{Key: "2fa", Value: "JBSWY3DPEHPK3PXP"},
+		},
+		s.Fields(),
+	)
+	assert.Equal(t, "https://example.com\nsee /etc/hosts:2", s.Body())
+	assert.Equal(t, "password\nusername:alice\n2fa:JBSWY3DPEHPK3PXP\nhttps://example.com\nsee /etc/hosts:2\n", s.String())
+}
+
+func TestFirstLineIsAlwaysThePassword(t *testing.T) {
+	s := secret.Parse("username: alice\nusername: bob")
+
+	assert.Equal(t, "username: alice", s.Password())
+
+	username, ok := s.Get("username")
+	assert.True(t, ok)
+	assert.Equal(t, "bob", username)
+}
+
+func TestGet(t *testing.T) {
+	s := secret.Parse(entry)
+
+	url, ok := s.Get("URL")
+	assert.True(t, ok)
+	assert.Equal(t, "https://example.com", url)
+
+	_, ok = s.Get("https")
+	assert.False(t, ok, "URLs are not fields")
+
+	_, ok = s.Get("missing")
+	assert.False(t, ok)
+}
+
+func TestSet(t *testing.T) {
+	s := secret.Parse(entry)
+
+	s.Set("url", "https://example.org")
+	s.Set("Notes", "none")
+	s.Set("email", "alice@example.com")
+
+	assert.Equal(t, `correct horse battery staple
+username: alice
+url:  https://example.org
+https://example.com/login
+Notes: none
+email: alice@example.com
+
+The security questions are in the safe.
+`, s.String())
+}
+
+func TestSetWithoutFields(t *testing.T) {
+	s := secret.Parse("password\nsome notes\n")
+
+	s.Set("username", "alice")
+
+	assert.Equal(t, "password\nusername: alice\nsome notes\n", s.String())
+}
+
+func TestSetPassword(t *testing.T) {
+	s := secret.Parse(entry)
+
+	s.SetPassword("new password")
+
+	assert.Equal(t, "new password", s.Password())
+	assert.Equal(t, "alice", s.Fields()[0].Value)
+}
+
+func TestDelete(t *testing.T) {
+	s := secret.Parse("password\nkey: 1\nother: 2\nKEY: 3\nbody\n")
+
+	assert.True(t, s.Delete("key"))
+	assert.False(t, s.Delete("key"))
+	assert.False(t, s.Delete(""))
+
+	assert.Equal(t, "password\nother: 2\nbody\n", s.String())
+}
+
+func TestMarshalJSON(t *testing.T) {
+	content, err := json.Marshal(secret.Parse("password\nusername: alice\nnotes\n"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, `{"password":"password","fields":[{"key":"username","value":"alice"}],"body":"notes"}`, string(content))
+
+	content, err = json.Marshal(secret.Parse("password"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, `{"password":"password","fields":[],"body":""}`, string(content))
+}
+
+func TestLine(t *testing.T) {
+	s := secret.Parse("password\nusername: alice\n\nnotes\n")
+
+	assert.Equal(t, 4, s.LineCount())
+
+	for n, expected := range map[int]string{1: "password", 2: "username: alice", 3: "", 4: "notes"} {
+		line, ok := s.Line(n)
+		assert.True(t, ok)
+		assert.Equal(t, expected, line)
+	}
+
+	_, ok := s.Line(0)
+	assert.False(t, ok)
+
+	_, ok = s.Line(5)
+	assert.False(t, ok, "the newline ending the entry does not start a line")
+}
+
+func TestSetLine(t *testing.T) {
+	s := secret.Parse("password\nnotes\n")
+
+	assert.True(t, s.SetLine(2, "url: https://example.com"))
+	assert.False(t, s.SetLine(3, "more notes"))
+
+	url, _ := s.Get("url")
+	assert.Equal(t, "https://example.com", url)
+	assert.Equal(t, "password\nurl: https://example.com\n", s.String())
+}
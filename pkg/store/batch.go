@@ -0,0 +1,179 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package store
+
+import (
+	"context"
+	"fmt"
+)
+
+// Batch stages changes to a PasswordStore and applies them together with a
+// single commit. If any of them fails, every file they touched is restored.
+type Batch struct {
+	store      *PasswordStore
+	operations []batchOperation
+}
+
+// batchOperation applies one change to the store of a batch.
+type batchOperation func(ctx context.Context, store *PasswordStore) error
+
+// NewBatch returns an empty Batch for the store.
+func (store *PasswordStore) NewBatch() *Batch {
+	return &Batch{store: store}
+}
+
+// Len returns the number of staged changes.
+func (batch *Batch) Len() int {
+	return len(batch.operations)
+}
+
+func (batch *Batch) add(operation batchOperation) {
+	batch.operations = append(batch.operations, operation)
+}
+
+// SetGPGIDs stages setting the store's GPG ids and reencrypting the
+// passwords that use them.
+func (batch *Batch) SetGPGIDs(gpgIDs []string, opts ...ReencryptOption) {
+	options := newReencryptOptions(opts)
+	batch.add(func(ctx context.Context, store *PasswordStore) error {
+		return store.setDirectoryGPGIDs(ctx, ".", gpgIDs, options)
+	})
+}
+
+// SetDirectoryGPGIDs stages setting the GPG ids of a directory and
+// reencrypting the passwords that use them.
+func (batch *Batch) SetDirectoryGPGIDs(dirname string, gpgIDs []string, opts ...ReencryptOption) {
+	options := newReencryptOptions(opts)
+	batch.add(func(ctx context.Context, store *PasswordStore) error {
+		if isRootName(dirname) {
+			return directoryError(dirname, ErrInvalidName)
+		}
+		return store.setDirectoryGPGIDs(ctx, dirname, gpgIDs, options)
+	})
+}
+
+// InsertPassword stages inserting or overwriting a password.
+func (batch *Batch) InsertPassword(pwname, pwtext string) {
+	batch.add(func(ctx context.Context, store *PasswordStore) error {
+		return store.InsertPasswordContext(ctx, pwname, pwtext)
+	})
+}
+
+// ReencryptPassword stages reencrypting a password to the GPG ids of its
+// directory.
+func (batch *Batch) ReencryptPassword(pwname string) {
+	batch.add(func(ctx context.Context, store *PasswordStore) error {
+		return store.ReencryptPasswordContext(ctx, pwname)
+	})
+}
+
+// ReencryptPasswords stages reencrypting passwords using a pool of
+// workers.
+func (batch *Batch) ReencryptPasswords(passwords []string, opts ...ReencryptOption) {
+	options := newReencryptOptions(opts)
+	batch.add(func(ctx context.Context, store *PasswordStore) error {
+		_, err := store.reencryptPasswords(ctx, passwords, options)
+		return err
+	})
+}
+
+// RemovePassword stages removing a password.
+func (batch *Batch) RemovePassword(pwname string) {
+	batch.add(func(ctx context.Context, store *PasswordStore) error {
+		return store.RemovePasswordContext(ctx, pwname)
+	})
+}
+
+// RemoveDirectory stages removing a directory.
+func (batch *Batch) RemoveDirectory(dirname string) {
+	batch.add(func(ctx context.Context, store *PasswordStore) error {
+		return store.RemoveDirectoryContext(ctx, dirname)
+	})
+}
+
+// MovePassword stages moving a password.
+func (batch *Batch) MovePassword(source, dest string, opts ...MoveOption) {
+	batch.add(func(ctx context.Context, store *PasswordStore) error {
+		return store.MovePasswordContext(ctx, source, dest, opts...)
+	})
+}
+
+// MoveDirectory stages moving a directory.
+func (batch *Batch) MoveDirectory(source, dest string, opts ...MoveOption) {
+	options := newMoveOptions(opts)
+	batch.add(func(ctx context.Context, store *PasswordStore) error {
+		return store.moveDirectory(ctx, source, dest, options)
+	})
+}
+
+// CopyPassword stages copying a password.
+func (batch *Batch) CopyPassword(source, dest string, opts ...MoveOption) {
+	batch.add(func(ctx context.Context, store *PasswordStore) error {
+		return store.CopyPasswordContext(ctx, source, dest, opts...)
+	})
+}
+
+// CopyDirectory stages copying a directory.
+func (batch *Batch) CopyDirectory(source, dest string, opts ...MoveOption) {
+	options := newMoveOptions(opts)
+	batch.add(func(ctx context.Context, store *PasswordStore) error {
+		return store.copyDirectoryTo(ctx, source, dest, options)
+	})
+}
+
+// Apply applies the staged changes in order and commits them with message.
+func (batch *Batch) Apply(message string) error {
+	return batch.ApplyContext(context.Background(), message)
+}
+
+// ApplyContext is like Apply but stops when ctx is done. The changes that
+// were already applied are then rolled back.
+func (batch *Batch) ApplyContext(ctx context.Context, message string) error {
+	journal := newJournalStorage(batch.store.Storage)
+
+	// The changes are applied to a copy of the store that writes through the
+	// journal and does not commit.
+	batchStore := *batch.store
+	batchStore.Storage = journal
+	batchStore.VCS = NoopVCS{}
+
+	err := func() error {
+		for _, operation := range batch.operations {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := operation(ctx, &batchStore); err != nil {
+				return err
+			}
+		}
+		return batch.store.AddAndCommitContext(ctx, message, journal.changed()...)
+	}()
+	if err == nil {
+		batch.store.GPGIDs = batchStore.GPGIDs
+		return nil
+	}
+
+	if rollbackErr := journal.rollback(); rollbackErr != nil {
+		return fmt.Errorf("%w (could not roll back: %v)", err, rollbackErr)
+	}
+
+	// Stage the restored files in case the failed commit staged the changes.
+	batch.store.vcs().Add(context.Background(), journal.changed()...)
+
+	return err
+}
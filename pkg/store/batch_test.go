@@ -0,0 +1,161 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package store_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aviau/gopass/pkg/store"
+)
+
+func TestBatchRollback(t *testing.T) {
+	passwordStore, _ := newRecordingPasswordStore(t)
+
+	if err := os.Mkdir(filepath.Join(passwordStore.Path, "dir"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, pwname := range []string{"a.com", "b.com", "dir/c.com"} {
+		if err := passwordStore.InsertPassword(pwname, "password "+pwname); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	batch := passwordStore.NewBatch()
+	batch.SetGPGIDs([]string{"new"})
+	batch.ReencryptPassword("a.com")
+	batch.MovePassword("b.com", "moved.com")
+	batch.MoveDirectory("dir", "dir2")
+	batch.InsertPassword("new.com", "password")
+	batch.RemovePassword("missing.com")
+
+	err := batch.Apply("batch")
+	assert.True(t, errors.Is(err, store.ErrNotFound))
+
+	assert.Equal(t, []string{"root"}, passwordStore.GPGIDs)
+
+	gpgIDContent, err := ioutil.ReadFile(filepath.Join(passwordStore.Path, ".gpg-id"))
+	assert.Nil(t, err)
+	assert.Equal(t, "root\n", string(gpgIDContent))
+
+	assert.Equal(t, []string{"a.com", "b.com", "dir/c.com"}, passwordStore.GetPasswordsList())
+
+	decryptedPassword, err := passwordStore.GetPassword("dir/c.com")
+	assert.Nil(t, err)
+	assert.Equal(t, "password dir/c.com", decryptedPassword)
+
+	_, err = os.Stat(filepath.Join(passwordStore.Path, "dir2"))
+	assert.True(t, os.IsNotExist(err), "dir2 should have been removed")
+}
+
+func TestBatchRollbackNewDirectory(t *testing.T) {
+	passwordStore, _ := newRecordingPasswordStore(t)
+
+	if err := os.Mkdir(filepath.Join(passwordStore.Path, "dir"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := passwordStore.InsertPassword("dir/a.com", "password"); err != nil {
+		t.Fatal(err)
+	}
+
+	batch := passwordStore.NewBatch()
+	batch.MoveDirectory("dir", "dir2")
+	batch.ReencryptPassword("dir2/a.com")
+	batch.RemovePassword("missing.com")
+
+	err := batch.Apply("batch")
+	assert.True(t, errors.Is(err, store.ErrNotFound))
+
+	assert.Equal(t, []string{"dir/a.com"}, passwordStore.GetPasswordsList())
+
+	_, err = os.Stat(filepath.Join(passwordStore.Path, "dir2"))
+	assert.True(t, os.IsNotExist(err), "dir2 should have been removed")
+}
+
+// cancelingGPGBackend is a GPGBackend that cancels a context when it
+// encrypts its second password.
+type cancelingGPGBackend struct {
+	recordingGPGBackend
+	cancel  context.CancelFunc
+	encrypt int
+}
+
+func (backend *cancelingGPGBackend) Encrypt(content []byte, recipients []string) ([]byte, error) {
+	backend.encrypt++
+	if backend.encrypt == 2 {
+		backend.cancel()
+	}
+	return backend.recordingGPGBackend.Encrypt(content, recipients)
+}
+
+func TestBatchRollbackCanceled(t *testing.T) {
+	passwordStore, _ := newMemoryPasswordStore(t)
+
+	if err := passwordStore.InsertPassword("a.com", "password"); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	passwordStore.GPGBackend = &cancelingGPGBackend{cancel: cancel}
+
+	batch := passwordStore.NewBatch()
+	batch.RemovePassword("a.com")
+	batch.InsertPassword("b.com", "password")
+	batch.InsertPassword("c.com", "password")
+	batch.InsertPassword("d.com", "password")
+
+	err := batch.ApplyContext(ctx, "batch")
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, []string{"a.com"}, passwordStore.GetPasswordsList())
+}
+
+func TestBatchSingleCommit(t *testing.T) {
+	passwordStore, _ := newGitPasswordStore(t)
+
+	if err := passwordStore.InsertPassword("a.com", "password"); err != nil {
+		t.Fatal(err)
+	}
+
+	batch := passwordStore.NewBatch()
+	batch.InsertPassword("b.com", "password")
+	batch.MovePassword("a.com", "c.com")
+	batch.SetGPGIDs([]string{"new"})
+
+	if err := batch.Apply("batch"); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"new"}, passwordStore.GPGIDs)
+	assert.Equal(t, []string{"b.com", "c.com"}, passwordStore.GetPasswordsList())
+
+	log, err := passwordStore.VCS.Log(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"batch", "added password \"a.com\"", "initial commit"}, log)
+
+	status, err := passwordStore.VCS.Status(context.Background())
+	assert.Nil(t, err)
+	assert.Empty(t, status)
+}
@@ -0,0 +1,72 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// blockingGPGBackend is a ContextGPGBackend that blocks until its context is
+// done.
+type blockingGPGBackend struct {
+	recordingGPGBackend
+}
+
+func (backend *blockingGPGBackend) EncryptContext(ctx context.Context, content []byte, recipients []string) ([]byte, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (backend *blockingGPGBackend) DecryptContext(ctx context.Context, content []byte) ([]byte, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestInsertPasswordContextCanceled(t *testing.T) {
+	passwordStore, backend := newRecordingPasswordStore(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := passwordStore.InsertPasswordContext(ctx, "test.com", "password")
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Nil(t, backend.recipients, "the backend should not have been called")
+
+	containsPassword, _ := passwordStore.ContainsPassword("test.com")
+	assert.False(t, containsPassword, "test.com should not have been inserted")
+}
+
+func TestContextGPGBackendInterrupted(t *testing.T) {
+	passwordStore, _ := newRecordingPasswordStore(t)
+
+	if err := passwordStore.InsertPassword("test.com", "password"); err != nil {
+		t.Fatal(err)
+	}
+
+	passwordStore.GPGBackend = &blockingGPGBackend{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go cancel()
+
+	_, err := passwordStore.GetPasswordContext(ctx, "test.com")
+	assert.True(t, errors.Is(err, context.Canceled))
+}
@@ -0,0 +1,97 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package store
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors returned by the PasswordStore. They are usually wrapped in an
+// *EntryError and should be tested with errors.Is.
+var (
+	// ErrNotFound means that a password or directory does not exist.
+	ErrNotFound = errors.New("not found")
+
+	// ErrAlreadyExists means that a password, directory or .gpg-id file
+	// already exists.
+	ErrAlreadyExists = errors.New("already exists")
+
+	// ErrNotInitialized means that there are no GPG ids to encrypt to.
+	ErrNotInitialized = errors.New("password store is not initialized")
+
+	// ErrDecrypt means that the GPG backend could not decrypt a password.
+	ErrDecrypt = errors.New("could not decrypt")
+
+	// ErrEncrypt means that the GPG backend could not encrypt a password.
+	ErrEncrypt = errors.New("could not encrypt")
+
+	// ErrInvalidName means that the name of a password or directory is not
+	// allowed, for example because it points outside of the store.
+	ErrInvalidName = errors.New("invalid name")
+
+	// ErrUnsupported means that the GPG backend does not support an
+	// operation.
+	ErrUnsupported = errors.New("not supported by the GPG backend")
+
+	// ErrUnknownKey means that a GPG id does not match any key in the
+	// keyring.
+	ErrUnknownKey = errors.New("no such key in the keyring")
+
+	// ErrBadSignature means that a .gpg-id file is not signed by one of the
+	// store's signing keys.
+	ErrBadSignature = errors.New("not signed by a signing key")
+)
+
+// EntryError records an error and the password or directory that caused it.
+type EntryError struct {
+	Kind string // "password" or "directory"
+	Name string // The name of the password or directory in the store
+	Err  error
+}
+
+func (e *EntryError) Error() string {
+	return fmt.Sprintf("%s \"%s\": %s", e.Kind, e.Name, e.Err)
+}
+
+func (e *EntryError) Unwrap() error {
+	return e.Err
+}
+
+// passwordError returns an *EntryError for a password.
+func passwordError(pwname string, err error) error {
+	return &EntryError{Kind: "password", Name: pwname, Err: err}
+}
+
+// directoryError returns an *EntryError for a directory.
+func directoryError(dirname string, err error) error {
+	return &EntryError{Kind: "directory", Name: dirname, Err: err}
+}
+
+// backendError wraps an error returned by the GPG backend with sentinel, one
+// of ErrDecrypt or ErrEncrypt.
+func backendError(sentinel, err error) error {
+	return fmt.Errorf("%w: %s", sentinel, err)
+}
+
+// Errors returned by the MemoryStorage, in *os.PathError.
+var (
+	errIsDirectory       = errors.New("is a directory")
+	errNotDirectory      = errors.New("not a directory")
+	errDirectoryNotEmpty = errors.New("directory not empty")
+)
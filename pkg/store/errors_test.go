@@ -0,0 +1,100 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package store_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aviau/gopass/pkg/store"
+)
+
+// failingGPGBackend is a GPGBackend that always fails.
+type failingGPGBackend struct{}
+
+func (backend *failingGPGBackend) Encrypt(content []byte, recipients []string) ([]byte, error) {
+	return nil, errors.New("encryption failed")
+}
+
+func (backend *failingGPGBackend) Decrypt(content []byte) ([]byte, error) {
+	return nil, errors.New("decryption failed")
+}
+
+func TestGetPasswordErrNotFound(t *testing.T) {
+	passwordStore, _ := newRecordingPasswordStore(t)
+
+	_, err := passwordStore.GetPassword("test.com")
+	assert.True(t, errors.Is(err, store.ErrNotFound))
+
+	var entryError *store.EntryError
+	assert.True(t, errors.As(err, &entryError))
+	assert.Equal(t, "test.com", entryError.Name)
+	assert.EqualError(t, err, "password \"test.com\": not found")
+}
+
+func TestGetPasswordErrDecrypt(t *testing.T) {
+	passwordStore, _ := newRecordingPasswordStore(t)
+
+	if err := passwordStore.InsertPassword("test.com", "password"); err != nil {
+		t.Fatal(err)
+	}
+
+	passwordStore.GPGBackend = &failingGPGBackend{}
+
+	_, err := passwordStore.GetPassword("test.com")
+	assert.True(t, errors.Is(err, store.ErrDecrypt))
+	assert.False(t, errors.Is(err, store.ErrNotFound))
+
+	var entryError *store.EntryError
+	assert.True(t, errors.As(err, &entryError))
+	assert.Equal(t, "test.com", entryError.Name)
+}
+
+func TestInsertPasswordErrEncrypt(t *testing.T) {
+	passwordStore, _ := newRecordingPasswordStore(t)
+	passwordStore.GPGBackend = &failingGPGBackend{}
+
+	err := passwordStore.InsertPassword("test.com", "password")
+	assert.True(t, errors.Is(err, store.ErrEncrypt))
+}
+
+func TestInsertPasswordErrNotInitialized(t *testing.T) {
+	passwordStore := store.NewPasswordStore(t.TempDir())
+	passwordStore.UsesGit = false
+	passwordStore.GPGBackend = &recordingGPGBackend{}
+
+	err := passwordStore.InsertPassword("test.com", "password")
+	assert.True(t, errors.Is(err, store.ErrNotInitialized))
+}
+
+func TestInitErrAlreadyExists(t *testing.T) {
+	passwordStore, _ := newRecordingPasswordStore(t)
+
+	err := passwordStore.Init([]string{"test"})
+	assert.True(t, errors.Is(err, store.ErrAlreadyExists))
+}
+
+func TestRemoveDirectoryErrNotFound(t *testing.T) {
+	passwordStore, _ := newRecordingPasswordStore(t)
+
+	err := passwordStore.RemoveDirectory("dir")
+	assert.True(t, errors.Is(err, store.ErrNotFound))
+	assert.EqualError(t, err, "directory \"dir\": not found")
+}
@@ -0,0 +1,358 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package store
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ProblemKind is a kind of problem found by Fsck.
+type ProblemKind string
+
+// Problems found by Fsck.
+const (
+	ProblemDecrypt        ProblemKind = "can't be decrypted"
+	ProblemRecipients     ProblemKind = "is not encrypted to the GPG ids of its directory"
+	ProblemPermissions    ProblemKind = "is accessible by other users"
+	ProblemStrayFile      ProblemKind = "is not a password"
+	ProblemEmptyDirectory ProblemKind = "is an empty directory"
+	ProblemUncommitted    ProblemKind = "has uncommitted changes"
+)
+
+// Problem is a problem found by Fsck.
+type Problem struct {
+	Kind   ProblemKind // What is wrong
+	Name   string      // The file or directory, relative to the root of the store
+	Detail string      // More information about the problem, if any
+	Fixed  bool        // Whether or not the problem was fixed
+}
+
+func (p Problem) String() string {
+	s := fmt.Sprintf("\"%s\" %s", p.Name, p.Kind)
+	if p.Detail != "" {
+		s += ": " + p.Detail
+	}
+	if p.Fixed {
+		s += " (fixed)"
+	}
+	return s
+}
+
+// FsckOption configures Fsck.
+type FsckOption func(*fsckOptions)
+
+type fsckOptions struct {
+	fix bool
+}
+
+// WithFix sets whether or not Fsck fixes the problems it can: passwords are
+// reencrypted, permissions are restricted, empty directories are removed and
+// changes are committed. Defaults to false.
+func WithFix(fix bool) FsckOption {
+	return func(opts *fsckOptions) {
+		opts.fix = fix
+	}
+}
+
+// Fsck checks the integrity of the store and returns the problems it found.
+// Recipients are only checked if the GPG backend is a RecipientsGPGBackend.
+func (store *PasswordStore) Fsck(opts ...FsckOption) ([]Problem, error) {
+	return store.FsckContext(context.Background(), opts...)
+}
+
+// FsckContext is like Fsck but stops when ctx is done.
+func (store *PasswordStore) FsckContext(ctx context.Context, opts ...FsckOption) ([]Problem, error) {
+	options := &fsckOptions{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	problems, err := store.fsckFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	keyIDs := make(map[string][]string)
+	for _, password := range store.GetPasswordsList() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		passwordProblems, err := store.fsckPassword(ctx, password, keyIDs)
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, passwordProblems...)
+	}
+
+	status, err := store.vcs().Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range status {
+		problems = append(problems, Problem{Kind: ProblemUncommitted, Name: name})
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].Name < problems[j].Name
+	})
+
+	if options.fix {
+		if err := store.fsckFix(ctx, problems); err != nil {
+			return problems, err
+		}
+	}
+
+	return problems, nil
+}
+
+// fsckFiles looks for files and directories with bad permissions, stray
+// files and empty directories.
+func (store *PasswordStore) fsckFiles() ([]Problem, error) {
+	var problems []Problem
+	var directories []string
+	children := make(map[string]int)
+
+	err := store.Storage.Walk(".", func(name string, fileInfo os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if name == "." {
+			return nil
+		}
+
+		children[path.Dir(name)]++
+		base := path.Base(name)
+
+		if fileInfo.IsDir() {
+			if name == ".git" {
+				return filepath.SkipDir
+			}
+			directories = append(directories, name)
+		} else if !strings.HasSuffix(base, ".gpg") && !isStoreFile(name) {
+			problems = append(problems, Problem{Kind: ProblemStrayFile, Name: name})
+			return nil
+		} else if !strings.HasSuffix(base, ".gpg") {
+			return nil
+		}
+
+		if fileInfo.Mode().Perm()&0077 != 0 {
+			problems = append(problems, Problem{
+				Kind:   ProblemPermissions,
+				Name:   name,
+				Detail: fmt.Sprintf("mode is %04o", fileInfo.Mode().Perm()),
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, directory := range directories {
+		if children[directory] == 0 {
+			problems = append(problems, Problem{Kind: ProblemEmptyDirectory, Name: directory})
+		}
+	}
+
+	return problems, nil
+}
+
+// isStoreFile returns whether or not a file that is not a password belongs
+// in the store.
+func isStoreFile(name string) bool {
+	switch name {
+	case ".gitattributes", ".gitignore":
+		return true
+	}
+	switch path.Base(name) {
+	case ".gpg-id", ".gpg-id.sig":
+		return true
+	}
+	return false
+}
+
+// fsckPassword makes sure that a password can be decrypted and that it is
+// encrypted to the GPG ids of its directory. keyIDs caches the key IDs of
+// GPG ids.
+func (store *PasswordStore) fsckPassword(ctx context.Context, password string, keyIDs map[string][]string) ([]Problem, error) {
+	var problems []Problem
+	name := password + ".gpg"
+
+	encryptedPassword, err := store.Storage.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := store.decrypt(ctx, encryptedPassword); ctx.Err() != nil {
+		return nil, ctx.Err()
+	} else if err != nil {
+		problems = append(problems, Problem{Kind: ProblemDecrypt, Name: name, Detail: err.Error()})
+	}
+
+	backend, ok := store.GPGBackend.(RecipientsGPGBackend)
+	if !ok {
+		return problems, nil
+	}
+
+	detail, err := store.checkRecipients(ctx, backend, password, encryptedPassword, keyIDs)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	} else if err != nil {
+		detail = err.Error()
+	}
+	if detail != "" {
+		problems = append(problems, Problem{Kind: ProblemRecipients, Name: name, Detail: detail})
+	}
+
+	return problems, nil
+}
+
+// checkRecipients returns what is wrong with the recipients of a password,
+// or an empty string if it is encrypted to exactly one key of each of the GPG
+// ids of its directory.
+func (store *PasswordStore) checkRecipients(ctx context.Context, backend RecipientsGPGBackend, password string, encryptedPassword []byte, keyIDs map[string][]string) (string, error) {
+	gpgIDs, err := store.GPGIDsForPassword(password)
+	if err != nil {
+		return "", err
+	}
+
+	return recipientsProblems(ctx, backend, gpgIDs, encryptedPassword, keyIDs)
+}
+
+// recipientsProblems returns what is wrong with the recipients of an
+// encrypted password, or an empty string if it is encrypted to exactly one
+// key of each of gpgIDs.
+func recipientsProblems(ctx context.Context, backend RecipientsGPGBackend, gpgIDs []string, encryptedPassword []byte, keyIDs map[string][]string) (string, error) {
+	recipients, err := backend.Recipients(ctx, encryptedPassword)
+	if err != nil {
+		return "", err
+	}
+
+	isRecipient := make(map[string]bool)
+	for _, recipient := range recipients {
+		isRecipient[strings.ToUpper(recipient)] = true
+	}
+
+	var missing []string
+	expected := make(map[string]bool)
+	for _, gpgID := range gpgIDs {
+		ids, ok := keyIDs[gpgID]
+		if !ok {
+			if ids, err = backend.KeyIDs(ctx, gpgID); err != nil {
+				return "", err
+			}
+			keyIDs[gpgID] = ids
+		}
+
+		found := false
+		for _, id := range ids {
+			id = strings.ToUpper(id)
+			expected[id] = true
+			found = found || isRecipient[id]
+		}
+		if !found {
+			missing = append(missing, gpgID)
+		}
+	}
+
+	var unexpected []string
+	for _, recipient := range recipients {
+		if !expected[strings.ToUpper(recipient)] {
+			unexpected = append(unexpected, recipient)
+		}
+	}
+
+	var details []string
+	if len(missing) > 0 {
+		details = append(details, "not encrypted to "+strings.Join(missing, ", "))
+	}
+	if len(unexpected) > 0 {
+		details = append(details, "also encrypted to "+strings.Join(unexpected, ", "))
+	}
+
+	return strings.Join(details, "; "), nil
+}
+
+// fsckFix fixes the problems that can be fixed and commits the changes.
+func (store *PasswordStore) fsckFix(ctx context.Context, problems []Problem) error {
+	var changed []string
+	var committed []*Problem
+
+	// Stray files may be secrets in plaintext, they must never be committed.
+	stray := make(map[string]bool)
+	for _, problem := range problems {
+		if problem.Kind == ProblemStrayFile {
+			stray[problem.Name] = true
+		}
+	}
+
+	for i := range problems {
+		problem := &problems[i]
+
+		switch problem.Kind {
+		case ProblemRecipients:
+			password := strings.TrimSuffix(problem.Name, ".gpg")
+			if err := store.ReencryptPasswordContext(ctx, password); err != nil {
+				return err
+			}
+			changed = append(changed, problem.Name)
+			committed = append(committed, problem)
+		case ProblemPermissions:
+			fileInfo, err := store.Storage.Stat(problem.Name)
+			if err != nil {
+				return err
+			}
+			if err := store.Storage.Chmod(problem.Name, fileInfo.Mode().Perm()&^0077); err != nil {
+				return err
+			}
+			problem.Fixed = true
+		case ProblemEmptyDirectory:
+			if err := store.Storage.Remove(problem.Name); err != nil {
+				return err
+			}
+			problem.Fixed = true
+		case ProblemUncommitted:
+			if stray[problem.Name] {
+				continue
+			}
+			changed = append(changed, problem.Name)
+			committed = append(committed, problem)
+		}
+	}
+
+	if len(changed) == 0 {
+		return nil
+	}
+
+	if err := store.AddAndCommitContext(ctx, "Fix problems found by fsck", changed...); err != nil {
+		return err
+	}
+
+	for _, problem := range committed {
+		problem.Fixed = true
+	}
+
+	return nil
+}
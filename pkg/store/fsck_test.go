@@ -0,0 +1,194 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package store_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aviau/gopass/pkg/store"
+	"github.com/stretchr/testify/assert"
+)
+
+// keyIDsGPGBackend is a taggingGPGBackend that can list recipients. The key
+// ID of a GPG id is the GPG id itself, the "unknown" key is not in the keyring
+// and only the "root" key has a user ID.
+type keyIDsGPGBackend struct {
+	taggingGPGBackend
+}
+
+func (backend *keyIDsGPGBackend) Recipients(ctx context.Context, content []byte) ([]string, error) {
+	return strings.Split(string(content[:bytes.IndexByte(content, '\n')]), ","), nil
+}
+
+func (backend *keyIDsGPGBackend) KeyIDs(ctx context.Context, gpgID string) ([]string, error) {
+	if gpgID == "unknown" {
+		return nil, errors.New("no public key")
+	}
+	return []string{gpgID}, nil
+}
+
+func (backend *keyIDsGPGBackend) UserIDs(ctx context.Context, keyID string) ([]string, error) {
+	if keyID != "ROOT" {
+		return nil, nil
+	}
+	return []string{"Root <root@example.com>"}, nil
+}
+
+func problemKinds(problems []store.Problem) map[string]store.ProblemKind {
+	kinds := make(map[string]store.ProblemKind)
+	for _, problem := range problems {
+		kinds[problem.Name] = problem.Kind
+	}
+	return kinds
+}
+
+func TestFsckNoProblems(t *testing.T) {
+	passwordStore, _ := newTaggingPasswordStore(t, "test.com", "ops/test.com", "dir/test.com")
+	passwordStore.GPGBackend = &keyIDsGPGBackend{}
+
+	problems, err := passwordStore.Fsck()
+	assert.Nil(t, err)
+	assert.Empty(t, problems)
+}
+
+func TestFsckFindsProblems(t *testing.T) {
+	passwordStore, storage := newTaggingPasswordStore(t, "test.com")
+	passwordStore.GPGBackend = &keyIDsGPGBackend{}
+
+	files := map[string]string{
+		"ops/wrong.com.gpg":     "root\npassword",
+		"undecryptable.com.gpg": "root\nbad",
+		"notes.txt":             "stray",
+	}
+	for name, content := range files {
+		if err := storage.WriteFile(name, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := storage.Chmod("test.com.gpg", 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := passwordStore.Fsck()
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		map[string]store.ProblemKind{
+			"dir":                   store.ProblemEmptyDirectory,
+			"notes.txt":             store.ProblemStrayFile,
+			"ops/wrong.com.gpg":     store.ProblemRecipients,
+			"test.com.gpg":          store.ProblemPermissions,
+			"undecryptable.com.gpg": store.ProblemDecrypt,
+		},
+		problemKinds(problems),
+	)
+
+	for _, problem := range problems {
+		assert.False(t, problem.Fixed)
+		if problem.Name == "ops/wrong.com.gpg" {
+			assert.Equal(t, "not encrypted to ops; also encrypted to root", problem.Detail)
+		}
+	}
+
+	assert.Equal(t, "root", recipients(t, storage, "ops/wrong.com"), "fsck should not change anything without WithFix")
+}
+
+func TestFsckFix(t *testing.T) {
+	passwordStore, storage := newTaggingPasswordStore(t, "test.com")
+	passwordStore.GPGBackend = &keyIDsGPGBackend{}
+
+	if err := storage.WriteFile("ops/wrong.com.gpg", []byte("root\npassword"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.WriteFile("notes.txt", []byte("stray"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.Chmod("test.com.gpg", 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := passwordStore.Fsck(store.WithFix(true))
+	assert.Nil(t, err)
+
+	for _, problem := range problems {
+		assert.Equal(t, problem.Kind != store.ProblemStrayFile, problem.Fixed, problem.String())
+	}
+
+	assert.Equal(t, "ops", recipients(t, storage, "ops/wrong.com"))
+
+	fileInfo, err := storage.Stat("test.com.gpg")
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), fileInfo.Mode().Perm())
+
+	_, err = storage.Stat("dir")
+	assert.True(t, os.IsNotExist(err))
+
+	problems, err = passwordStore.Fsck()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]store.ProblemKind{"notes.txt": store.ProblemStrayFile}, problemKinds(problems))
+}
+
+func TestFsckFixCommits(t *testing.T) {
+	passwordStore, output := newGitPasswordStore(t)
+
+	if err := ioutil.WriteFile(filepath.Join(passwordStore.Path, "test.com.gpg"), []byte("password"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := passwordStore.Fsck()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]store.ProblemKind{"test.com.gpg": store.ProblemUncommitted}, problemKinds(problems))
+
+	problems, err = passwordStore.Fsck(store.WithFix(true))
+	assert.Nil(t, err)
+	assert.Len(t, problems, 1)
+	assert.True(t, problems[0].Fixed)
+	assert.Contains(t, output.String(), "Fix problems found by fsck")
+
+	problems, err = passwordStore.Fsck()
+	assert.Nil(t, err)
+	assert.Empty(t, problems)
+}
+
+func TestFsckFixDoesNotCommitStrayFiles(t *testing.T) {
+	passwordStore, output := newGitPasswordStore(t)
+
+	for _, name := range []string{"test.com.gpg", "plaintext-notes.txt"} {
+		if err := ioutil.WriteFile(filepath.Join(passwordStore.Path, name), []byte("password"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	problems, err := passwordStore.Fsck(store.WithFix(true))
+	assert.Nil(t, err)
+	assert.Contains(t, output.String(), "Fix problems found by fsck")
+	for _, problem := range problems {
+		assert.Equal(t, problem.Name == "test.com.gpg", problem.Fixed, problem)
+	}
+
+	status, err := passwordStore.VCS.Status(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"plaintext-notes.txt"}, status)
+}
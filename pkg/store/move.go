@@ -0,0 +1,254 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package store
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// MoveOption configures how passwords and directories are moved or copied.
+type MoveOption func(*moveOptions)
+
+type moveOptions struct {
+	reencrypt bool
+}
+
+func newMoveOptions(opts []MoveOption) *moveOptions {
+	options := &moveOptions{
+		reencrypt: true,
+	}
+	for _, fn := range opts {
+		fn(options)
+	}
+	return options
+}
+
+// WithReencrypt sets whether or not passwords are reencrypted when their
+// destination uses different GPG ids than their source. Defaults to true.
+func WithReencrypt(reencrypt bool) MoveOption {
+	return func(opts *moveOptions) {
+		opts.reencrypt = reencrypt
+	}
+}
+
+// passwordDestination returns the name and the path of a password that is
+// moved or copied from source to dest.
+func (store *PasswordStore) passwordDestination(source, dest string) (string, string, error) {
+	// If the dest ends with a '/', then it is a directory.
+	if strings.HasSuffix(dest, "/") {
+		_, file := filepath.Split(source)
+		dest = path.Join(dest, file)
+	}
+	return store.passwordPath(dest)
+}
+
+// needsReencryption returns whether or not a password must be reencrypted
+// when moved or copied from source to dest, and the GPG ids of dest.
+func (store *PasswordStore) needsReencryption(source, dest string, options *moveOptions) (bool, []string, error) {
+	if !options.reencrypt {
+		return false, nil, nil
+	}
+
+	sourceGPGIDs, err := store.GPGIDsForPassword(source)
+	if err != nil {
+		return false, nil, err
+	}
+
+	destGPGIDs, err := store.GPGIDsForPassword(dest)
+	if err != nil {
+		return false, nil, err
+	}
+
+	return !sameGPGIDs(sourceGPGIDs, destGPGIDs), destGPGIDs, nil
+}
+
+// gpgIDsForPasswordsInDirectory returns the GPG ids of every password in a
+// directory, indexed by their name relative to the directory.
+func (store *PasswordStore) gpgIDsForPasswordsInDirectory(dirname string) (map[string][]string, error) {
+	prefix := path.Clean(dirname) + "/"
+
+	passwordsGPGIDs := make(map[string][]string)
+	for _, password := range store.GetPasswordsList() {
+		if !strings.HasPrefix(password, prefix) {
+			continue
+		}
+
+		gpgIDs, err := store.GPGIDsForPassword(password)
+		if err != nil {
+			return nil, err
+		}
+
+		passwordsGPGIDs[strings.TrimPrefix(password, prefix)] = gpgIDs
+	}
+
+	return passwordsGPGIDs, nil
+}
+
+// reencryptMovedPasswords reencrypts the passwords that were moved or copied
+// to the directory dest if their GPG ids differ from the ones they had
+// before, as returned by gpgIDsForPasswordsInDirectory.
+func (store *PasswordStore) reencryptMovedPasswords(ctx context.Context, sourceGPGIDs map[string][]string, dest string) error {
+	for password, gpgIDs := range sourceGPGIDs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		destPassword := path.Join(dest, password)
+
+		destGPGIDs, err := store.GPGIDsForPassword(destPassword)
+		if err != nil {
+			return err
+		}
+
+		if sameGPGIDs(gpgIDs, destGPGIDs) {
+			continue
+		}
+
+		destPasswordPath := destPassword + ".gpg"
+		if err := store.reencryptFile(ctx, destPasswordPath, destPasswordPath, destGPGIDs); err != nil {
+			return passwordError(destPassword, err)
+		}
+	}
+
+	return nil
+}
+
+// reencryptFile decrypts the password file sourcePath and writes it to
+// destPath encrypted for gpgIDs. The new ciphertext is verified before
+// destPath is replaced.
+func (store *PasswordStore) reencryptFile(ctx context.Context, sourcePath, destPath string, gpgIDs []string) error {
+	encryptedPassword, err := store.Storage.ReadFile(sourcePath)
+	if err != nil {
+		return fmt.Errorf("could not read encrypted password: %w", err)
+	}
+
+	decryptedPassword, err := store.decrypt(ctx, encryptedPassword)
+	if err != nil {
+		return err
+	}
+
+	reEncryptedPassword, err := store.encrypt(ctx, decryptedPassword, gpgIDs)
+	if err != nil {
+		return err
+	}
+
+	if err := store.verifyReencrypted(ctx, decryptedPassword, reEncryptedPassword, gpgIDs); err != nil {
+		return fmt.Errorf("could not verify the newly encrypted password: %w", err)
+	}
+
+	if err := store.Storage.WriteFile(destPath, reEncryptedPassword, 0600); err != nil {
+		return fmt.Errorf("could not write the newly encrypted password: %w", err)
+	}
+
+	return nil
+}
+
+// verifyReencrypted makes sure that encryptedPassword decrypts to password.
+// The operator can't decrypt it when they are not one of gpgIDs, in which
+// case its recipients are checked instead if the backend can list them.
+func (store *PasswordStore) verifyReencrypted(ctx context.Context, password, encryptedPassword []byte, gpgIDs []string) error {
+	verifiedPassword, err := store.decrypt(ctx, encryptedPassword)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	} else if err == nil {
+		if !bytes.Equal(verifiedPassword, password) {
+			return errors.New("decrypted content differs")
+		}
+		return nil
+	}
+
+	backend, ok := store.GPGBackend.(RecipientsGPGBackend)
+	if !ok {
+		return err
+	}
+
+	detail, err := recipientsProblems(ctx, backend, gpgIDs, encryptedPassword, make(map[string][]string))
+	if err != nil {
+		return err
+	} else if detail != "" {
+		return errors.New(detail)
+	}
+
+	return nil
+}
+
+// copyFile copies a file of the storage, keeping its permissions.
+func (store *PasswordStore) copyFile(source, dest string) error {
+	fileInfo, err := store.Storage.Stat(source)
+	if err != nil {
+		return err
+	}
+
+	content, err := store.Storage.ReadFile(source)
+	if err != nil {
+		return err
+	}
+
+	return store.Storage.WriteFile(dest, content, fileInfo.Mode().Perm())
+}
+
+// copyDirectory copies a directory of the storage and everything it
+// contains, and returns the path of the copy. Like cp -r, source is copied
+// inside of dest if dest already exists.
+func (store *PasswordStore) copyDirectory(source, dest string) (string, error) {
+	if fileInfo, err := store.Storage.Stat(dest); err == nil && fileInfo.IsDir() {
+		dest = path.Join(dest, path.Base(source))
+	}
+
+	return dest, store.Storage.Walk(source, func(name string, fileInfo os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		destName := path.Join(dest, strings.TrimPrefix(name, source))
+		if fileInfo.IsDir() {
+			return store.Storage.MkdirAll(destName, fileInfo.Mode().Perm())
+		}
+
+		return store.copyFile(name, destName)
+	})
+}
+
+// sameGPGIDs returns whether or not two lists contain the same GPG ids,
+// regardless of their order.
+func sameGPGIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	sortedA := append([]string(nil), a...)
+	sortedB := append([]string(nil), b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,109 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package store
+
+import (
+	"errors"
+	"path"
+	"strings"
+)
+
+// cleanName canonicalizes the name of a password or directory. Names are
+// relative to the root of the store, even when they start with a '/'. They may
+// not contain ".." or refer to dot files such as .git or .gpg-id.
+func cleanName(name string) (string, error) {
+	for _, part := range strings.Split(name, "/") {
+		if part == ".." || (strings.HasPrefix(part, ".") && part != ".") {
+			return "", ErrInvalidName
+		}
+	}
+
+	cleaned := strings.TrimPrefix(path.Clean("/"+name), "/")
+	if cleaned == "" {
+		return "", ErrInvalidName
+	}
+
+	return cleaned, nil
+}
+
+// isRootName returns whether or not a directory name refers to the root of
+// the store.
+func isRootName(dirname string) bool {
+	return path.Clean("/"+dirname) == "/"
+}
+
+// passwordPath validates the name of a password and returns its canonical
+// name and the name of its file in the storage.
+func (store *PasswordStore) passwordPath(pwname string) (string, string, error) {
+	// Names ending with a '/' are directories.
+	if strings.HasSuffix(pwname, "/") {
+		return "", "", passwordError(pwname, ErrInvalidName)
+	}
+
+	cleaned, err := cleanName(pwname)
+	if err != nil {
+		return "", "", passwordError(pwname, err)
+	}
+
+	return cleaned, cleaned + ".gpg", nil
+}
+
+// directoryPath validates the name of a directory and returns its canonical
+// name, which is also its name in the storage.
+func (store *PasswordStore) directoryPath(dirname string) (string, string, error) {
+	cleaned, err := cleanName(dirname)
+	if err != nil {
+		return "", "", directoryError(dirname, err)
+	}
+
+	return cleaned, cleaned, nil
+}
+
+// findPassword is like passwordPath but also fails with ErrNotFound if the
+// password does not exist.
+func (store *PasswordStore) findPassword(pwname string) (string, string, error) {
+	cleaned, passwordPath, err := store.passwordPath(pwname)
+	if err != nil {
+		return "", "", err
+	}
+
+	if fi, err := store.Storage.Stat(passwordPath); errors.Is(err, ErrInvalidName) {
+		return "", "", passwordError(pwname, err)
+	} else if err != nil || fi.IsDir() {
+		return "", "", passwordError(pwname, ErrNotFound)
+	}
+
+	return cleaned, passwordPath, nil
+}
+
+// findDirectory is like directoryPath but also fails with ErrNotFound if the
+// directory does not exist.
+func (store *PasswordStore) findDirectory(dirname string) (string, string, error) {
+	cleaned, directoryPath, err := store.directoryPath(dirname)
+	if err != nil {
+		return "", "", err
+	}
+
+	if fi, err := store.Storage.Stat(directoryPath); errors.Is(err, ErrInvalidName) {
+		return "", "", directoryError(dirname, err)
+	} else if err != nil || !fi.IsDir() {
+		return "", "", directoryError(dirname, ErrNotFound)
+	}
+
+	return cleaned, directoryPath, nil
+}
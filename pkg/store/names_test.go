@@ -0,0 +1,160 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package store_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aviau/gopass/pkg/store"
+)
+
+func TestInvalidPasswordNames(t *testing.T) {
+	passwordStore, _ := newRecordingPasswordStore(t)
+
+	for _, pwname := range []string{
+		"../test.com",
+		"dir/../../test.com",
+		"..",
+		".git/config",
+		"dir/.gpg-id",
+		".hidden",
+		"",
+		"/",
+		"dir/",
+	} {
+		_, err := passwordStore.GetPassword(pwname)
+		assert.True(t, errors.Is(err, store.ErrInvalidName), "GetPassword(%q)", pwname)
+
+		err = passwordStore.InsertPassword(pwname, "password")
+		assert.True(t, errors.Is(err, store.ErrInvalidName), "InsertPassword(%q)", pwname)
+
+		err = passwordStore.RemovePassword(pwname)
+		assert.True(t, errors.Is(err, store.ErrInvalidName), "RemovePassword(%q)", pwname)
+
+		containsPassword, _ := passwordStore.ContainsPassword(pwname)
+		assert.False(t, containsPassword, "ContainsPassword(%q)", pwname)
+	}
+}
+
+func TestInvalidDirectoryNames(t *testing.T) {
+	passwordStore, _ := newRecordingPasswordStore(t)
+
+	for _, dirname := range []string{
+		"..",
+		"../",
+		"dir/../..",
+		".git",
+		"",
+		"/",
+	} {
+		err := passwordStore.RemoveDirectory(dirname)
+		assert.True(t, errors.Is(err, store.ErrInvalidName), "RemoveDirectory(%q)", dirname)
+
+		err = passwordStore.SetDirectoryGPGIDs(dirname, []string{"test"})
+		assert.True(t, errors.Is(err, store.ErrInvalidName), "SetDirectoryGPGIDs(%q)", dirname)
+
+		containsDirectory, _ := passwordStore.ContainsDirectory(dirname)
+		assert.False(t, containsDirectory, "ContainsDirectory(%q)", dirname)
+	}
+
+	_, err := os.Stat(passwordStore.Path)
+	assert.Nil(t, err, "the store should not have been removed")
+}
+
+func TestMovePasswordOutsideOfStore(t *testing.T) {
+	passwordStore, _ := newRecordingPasswordStore(t)
+
+	if err := passwordStore.InsertPassword("test.com", "password"); err != nil {
+		t.Fatal(err)
+	}
+
+	err := passwordStore.MovePassword("test.com", "../test.com")
+	assert.True(t, errors.Is(err, store.ErrInvalidName))
+
+	err = passwordStore.CopyPassword("test.com", "../")
+	assert.True(t, errors.Is(err, store.ErrInvalidName))
+
+	containsPassword, _ := passwordStore.ContainsPassword("test.com")
+	assert.True(t, containsPassword, "test.com should not have been moved")
+}
+
+func TestSymlinkOutsideOfStore(t *testing.T) {
+	passwordStore, _ := newRecordingPasswordStore(t)
+
+	outside := t.TempDir()
+	if err := ioutil.WriteFile(filepath.Join(outside, "test.com.gpg"), []byte("outside"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Symlink(outside, filepath.Join(passwordStore.Path, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := passwordStore.GetPassword("link/test.com")
+	assert.True(t, errors.Is(err, store.ErrInvalidName))
+
+	err = passwordStore.InsertPassword("link/new.com", "password")
+	assert.True(t, errors.Is(err, store.ErrInvalidName))
+
+	err = passwordStore.RemoveDirectory("link")
+	assert.True(t, errors.Is(err, store.ErrInvalidName))
+
+	_, err = os.Stat(filepath.Join(outside, "test.com.gpg"))
+	assert.Nil(t, err, "the file outside of the store should not have been removed")
+}
+
+func TestDanglingSymlinkOutsideOfStore(t *testing.T) {
+	passwordStore, _ := newRecordingPasswordStore(t)
+
+	outsidePath := filepath.Join(t.TempDir(), "test.com.gpg")
+	if err := os.Symlink(outsidePath, filepath.Join(passwordStore.Path, "test.com.gpg")); err != nil {
+		t.Fatal(err)
+	}
+
+	err := passwordStore.InsertPassword("test.com", "password")
+	assert.True(t, errors.Is(err, store.ErrInvalidName))
+
+	_, err = os.Stat(outsidePath)
+	assert.True(t, os.IsNotExist(err), "no file should have been created outside of the store")
+}
+
+func TestSymlinkInsideOfStore(t *testing.T) {
+	passwordStore, _ := newRecordingPasswordStore(t)
+
+	if err := os.Mkdir(filepath.Join(passwordStore.Path, "dir"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Symlink("dir", filepath.Join(passwordStore.Path, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := passwordStore.InsertPassword("link/test.com", "password"); err != nil {
+		t.Fatal(err)
+	}
+
+	decryptedPassword, err := passwordStore.GetPassword("dir/test.com")
+	assert.Nil(t, err)
+	assert.Equal(t, "password", decryptedPassword)
+}
@@ -20,25 +20,26 @@ package store
 
 import (
 	"bufio"
+	"bytes"
+	"context"
 	"fmt"
-	"io/ioutil"
 	"os"
-	"os/exec"
 	"path"
-	"path/filepath"
 	"strings"
 
 	"github.com/aviau/gopass/internal/gpg"
-	gopassio "github.com/aviau/gopass/internal/io"
 )
 
 // PasswordStore represents a password store.
 type PasswordStore struct {
-	Path       string     // path of the store
-	GitDir     string     // The path of the git directory
-	GPGIDs     []string   // The GPG IDs used for the store
-	GPGBackend GPGBackend // The store's GPG backend.
-	UsesGit    bool       // Whether or not the store uses git
+	Path        string     // path of the store
+	GitDir      string     // The path of the git directory
+	GPGIDs      []string   // The GPG IDs used for the store
+	GPGBackend  GPGBackend // The store's GPG backend.
+	UsesGit     bool       // Whether or not the store uses git
+	Storage     Storage    // The store's storage layer.
+	VCS         VCS        // The store's version control system, used if UsesGit is true.
+	SigningKeys []string   // Fingerprints of the keys that must sign .gpg-id files, if any.
 }
 
 // GPGBackend the PasswordStore's GPG backend.
@@ -47,47 +48,163 @@ type GPGBackend interface {
 	Decrypt(content []byte) ([]byte, error)
 }
 
-// Returns the GPG ids for a given directory
-func loadGPGIDs(directory string) ([]string, error) {
-	gpgIDPath := path.Join(directory, ".gpg-id")
+// ContextGPGBackend is a GPGBackend that can be interrupted. The store uses
+// it instead of Encrypt and Decrypt when the backend implements it.
+type ContextGPGBackend interface {
+	GPGBackend
+	EncryptContext(ctx context.Context, content []byte, recipients []string) ([]byte, error)
+	DecryptContext(ctx context.Context, content []byte) ([]byte, error)
+}
+
+// RecipientsGPGBackend is a GPGBackend that can tell which keys a password
+// is encrypted to. Key IDs are long key IDs, in hexadecimal.
+type RecipientsGPGBackend interface {
+	GPGBackend
+	// Recipients returns the IDs of the keys content is encrypted to.
+	Recipients(ctx context.Context, content []byte) ([]string, error)
+	// KeyIDs returns the IDs of the keys and subkeys of a GPG id.
+	KeyIDs(ctx context.Context, gpgID string) ([]string, error)
+	// UserIDs returns the user IDs of the key that a key ID belongs to. It
+	// returns no user IDs if the key is not in the keyring.
+	UserIDs(ctx context.Context, keyID string) ([]string, error)
+}
+
+// encrypt encrypts content using the store's GPG backend. Errors wrap
+// ErrEncrypt, unless ctx is done.
+func (store *PasswordStore) encrypt(ctx context.Context, content []byte, recipients []string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var encrypted []byte
+	var err error
+	if backend, ok := store.GPGBackend.(ContextGPGBackend); ok {
+		encrypted, err = backend.EncryptContext(ctx, content, recipients)
+	} else {
+		encrypted, err = store.GPGBackend.Encrypt(content, recipients)
+	}
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	} else if err != nil {
+		return nil, backendError(ErrEncrypt, err)
+	}
+
+	return encrypted, nil
+}
 
-	file, err := os.Open(gpgIDPath)
+// decrypt decrypts content using the store's GPG backend. Errors wrap
+// ErrDecrypt, unless ctx is done.
+func (store *PasswordStore) decrypt(ctx context.Context, content []byte) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var decrypted []byte
+	var err error
+	if backend, ok := store.GPGBackend.(ContextGPGBackend); ok {
+		decrypted, err = backend.DecryptContext(ctx, content)
+	} else {
+		decrypted, err = store.GPGBackend.Decrypt(content)
+	}
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	} else if err != nil {
+		return nil, backendError(ErrDecrypt, err)
+	}
+
+	return decrypted, nil
+}
+
+// Returns the GPG ids for a given directory
+func (store *PasswordStore) loadGPGIDs(dirname string) ([]string, error) {
+	content, err := store.Storage.ReadFile(path.Join(dirname, ".gpg-id"))
 	if err != nil {
 		return nil, err
 	}
 
+	if err := store.verifyGPGIDs(context.Background(), dirname, content); err != nil {
+		return nil, err
+	}
+
 	var gpgIDs []string
 
-	fscanner := bufio.NewScanner(file)
+	fscanner := bufio.NewScanner(bytes.NewReader(content))
 	for fscanner.Scan() {
 		gpgID := strings.TrimSpace(fscanner.Text())
+		if gpgID == "" {
+			continue
+		}
 		gpgIDs = append(gpgIDs, gpgID)
 	}
 
-	return gpgIDs, nil
+	return gpgIDs, fscanner.Err()
+}
+
+// Writes the GPG ids of a given directory
+func (store *PasswordStore) writeGPGIDs(ctx context.Context, dirname string, gpgIDs []string) error {
+	var content strings.Builder
+	for _, gpgID := range gpgIDs {
+		content.WriteString(gpgID + "\n")
+	}
+
+	// The file is signed first so that nothing is written if it can't be.
+	signature, err := store.signGPGIDs(ctx, []byte(content.String()))
+	if err != nil {
+		return err
+	}
+
+	if err := store.Storage.WriteFile(
+		path.Join(dirname, ".gpg-id"),
+		[]byte(content.String()),
+		0644,
+	); err != nil {
+		return err
+	}
+
+	if signature == nil {
+		return nil
+	}
+
+	return store.Storage.WriteFile(path.Join(dirname, ".gpg-id.sig"), signature, 0644)
 }
 
 // NewPasswordStore returns a new password store.
 func NewPasswordStore(storePath string) *PasswordStore {
 	s := PasswordStore{}
 	s.Path = storePath
-	s.UsesGit = true
 	s.GitDir = path.Join(s.Path, ".git")
 	s.GPGBackend = gpg.New("", nil, false)
+	s.Storage = NewDiskStorage(storePath)
+	s.VCS = NewGitVCS(s.Path, s.GitDir, nil, nil)
 
 	//Read the .gpg-id file
-	gpgIDs, _ := loadGPGIDs(s.Path)
+	gpgIDs, _ := s.loadGPGIDs(".")
 	s.GPGIDs = gpgIDs
 
+	// As with pass, existing stores only use git if they have a git
+	// repository. New stores get one when they are initialized.
+	if gitDir, err := os.Stat(s.GitDir); err == nil {
+		s.UsesGit = gitDir.IsDir()
+	} else {
+		s.UsesGit = len(s.GPGIDs) == 0
+	}
+
 	return &s
 }
 
 // Init creates a Password Store at the Path
 func (store *PasswordStore) Init(gpgIDs []string) error {
+	return store.InitContext(context.Background(), gpgIDs)
+}
+
+// InitContext is like Init but stops when ctx is done.
+func (store *PasswordStore) InitContext(ctx context.Context, gpgIDs []string) error {
 	// Check if the password path already exists
-	if fi, err := os.Stat(store.Path); os.IsNotExist(err) {
+	if fi, err := store.Storage.Stat("."); os.IsNotExist(err) {
 		// Path does not exist, create it
-		if err := os.Mkdir(store.Path, 0700); err != nil {
+		if err := store.Storage.MkdirAll(".", 0700); err != nil {
 			return err
 		}
 	} else if err != nil {
@@ -102,79 +219,197 @@ func (store *PasswordStore) Init(gpgIDs []string) error {
 	}
 
 	// Check if the .gpg-id file already exists.
-	gpgIDFilePath := path.Join(store.Path, ".gpg-id")
-	if _, err := os.Stat(gpgIDFilePath); err == nil {
-		return fmt.Errorf("there is already a .gpg-id file at \"%s\". Stopping init", gpgIDFilePath)
+	if _, err := store.Storage.Stat(".gpg-id"); err == nil {
+		return fmt.Errorf("could not create password store. The .gpg-id file at \"%s\" %w", path.Join(store.Path, ".gpg-id"), ErrAlreadyExists)
 	} else if err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("could not look for an existing .gpg-id: %w", err)
 	}
 
-	gpgIDFile, err := os.Create(path.Join(store.Path, ".gpg-id"))
-	if err != nil {
+	if err := store.writeGPGIDs(ctx, ".", gpgIDs); err != nil {
 		return err
 	}
-	defer gpgIDFile.Close()
-	for _, gpgID := range gpgIDs {
-		gpgIDFile.WriteString(gpgID + "\n")
-	}
 	store.GPGIDs = gpgIDs
 
-	if err := store.git("init"); err != nil {
+	if err := store.vcs().Init(ctx); err != nil {
 		return err
 	}
 
-	return store.AddAndCommit("initial commit", ".gpg-id")
+	paths := []string{".gpg-id"}
+	if len(store.SigningKeys) > 0 {
+		paths = append(paths, ".gpg-id.sig")
+	}
+
+	return store.AddAndCommitContext(ctx, "initial commit", paths...)
 }
 
-// SetGPGIDs will set the store's GPG ids
-func (store *PasswordStore) SetGPGIDs(gpgIDs []string) error {
-	gpgIDFile, err := os.OpenFile(
-		path.Join(store.Path, ".gpg-id"),
-		os.O_WRONLY|os.O_CREATE|os.O_TRUNC,
-		0644,
-	)
+// SetGPGIDs will set the store's GPG ids and reencrypt the passwords that
+// use them. If any password fails to reencrypt, nothing is changed.
+func (store *PasswordStore) SetGPGIDs(gpgIDs []string, opts ...ReencryptOption) error {
+	return store.SetGPGIDsContext(context.Background(), gpgIDs, opts...)
+}
+
+// SetGPGIDsContext is like SetGPGIDs but stops when ctx is done.
+func (store *PasswordStore) SetGPGIDsContext(ctx context.Context, gpgIDs []string, opts ...ReencryptOption) error {
+	batch := store.NewBatch()
+	batch.SetGPGIDs(gpgIDs, opts...)
+
+	return batch.ApplyContext(ctx, fmt.Sprintf("Set GPG id to %s", strings.Join(gpgIDs, ", ")))
+}
+
+// SetDirectoryGPGIDs will set the GPG ids of a directory in the store by
+// writing its .gpg-id file, and reencrypt the passwords that use them. The
+// directory is created if it does not exist. If any password fails to
+// reencrypt, nothing is changed.
+func (store *PasswordStore) SetDirectoryGPGIDs(dirname string, gpgIDs []string, opts ...ReencryptOption) error {
+	return store.SetDirectoryGPGIDsContext(context.Background(), dirname, gpgIDs, opts...)
+}
+
+// SetDirectoryGPGIDsContext is like SetDirectoryGPGIDs but stops when ctx is done.
+func (store *PasswordStore) SetDirectoryGPGIDsContext(ctx context.Context, dirname string, gpgIDs []string, opts ...ReencryptOption) error {
+	cleaned, _, err := store.directoryPath(dirname)
 	if err != nil {
 		return err
 	}
-	defer gpgIDFile.Close()
 
-	for _, gpgID := range gpgIDs {
-		gpgIDFile.WriteString(gpgID + "\n")
+	batch := store.NewBatch()
+	batch.SetDirectoryGPGIDs(cleaned, gpgIDs, opts...)
+
+	return batch.ApplyContext(ctx, fmt.Sprintf("Set GPG id of \"%s\" to %s", cleaned, strings.Join(gpgIDs, ", ")))
+}
+
+// setDirectoryGPGIDs writes the .gpg-id file of a directory and reencrypts
+// the passwords that use it, without committing. The directory "." is the
+// root of the store.
+func (store *PasswordStore) setDirectoryGPGIDs(ctx context.Context, dirname string, gpgIDs []string, options *reencryptOptions) error {
+	if dirname != "." {
+		cleaned, directoryPath, err := store.directoryPath(dirname)
+		if err != nil {
+			return err
+		}
+		dirname = cleaned
+
+		if err := store.Storage.MkdirAll(directoryPath, 0700); err != nil {
+			return directoryError(dirname, err)
+		}
+	}
+
+	if err := store.writeGPGIDs(ctx, dirname, gpgIDs); err != nil {
+		return directoryError(dirname, err)
+	}
+
+	if dirname == "." {
+		store.GPGIDs = gpgIDs
+	}
+
+	passwords, err := store.passwordsUsingGPGIDFile(dirname)
+	if err != nil {
+		return err
 	}
-	store.GPGIDs = gpgIDs
 
-	return store.AddAndCommit(
-		fmt.Sprintf("Set GPG id to %s", strings.Join(gpgIDs, ", ")),
-		".gpg-id",
-	)
+	_, err = store.reencryptPasswords(ctx, passwords, options)
+	return err
 }
 
-// ReencryptPassword will reencrypt a password to the current GPG ids
-func (store *PasswordStore) ReencryptPassword(pwname string) error {
-	containsPassword, passwordPath := store.ContainsPassword(pwname)
+// gpgIDFileDirectory returns the directory holding the .gpg-id file that
+// applies to a directory, "." being the root of the store.
+func (store *PasswordStore) gpgIDFileDirectory(dirname string) (string, error) {
+	for dir := dirname; dir != "."; dir = path.Dir(dir) {
+		if _, err := store.Storage.Stat(path.Join(dir, ".gpg-id")); err == nil {
+			return dir, nil
+		} else if !os.IsNotExist(err) {
+			return "", err
+		}
+	}
+	return ".", nil
+}
+
+// passwordsUsingGPGIDFile returns the passwords encrypted using the .gpg-id
+// file of a directory.
+func (store *PasswordStore) passwordsUsingGPGIDFile(dirname string) ([]string, error) {
+	var passwords []string
+	for _, password := range store.GetPasswordsList() {
+		dir, err := store.gpgIDFileDirectory(path.Dir(password))
+		if err != nil {
+			return nil, err
+		}
+		if dir == dirname {
+			passwords = append(passwords, password)
+		}
+	}
+	return passwords, nil
+}
+
+// GPGIDsForDirectory returns the GPG ids used to encrypt passwords in a
+// directory. The nearest .gpg-id file in the directory or its parents is
+// used, falling back on the store's GPG ids at the root.
+func (store *PasswordStore) GPGIDsForDirectory(dirname string) ([]string, error) {
+	if isRootName(dirname) {
+		dirname = "."
+	} else {
+		var err error
+		if dirname, _, err = store.directoryPath(dirname); err != nil {
+			return nil, err
+		}
+	}
 
-	// Error if the password does not exist
-	if !containsPassword {
-		return fmt.Errorf("could not find password \"%s\" at path \"%s\"", pwname, passwordPath)
+	for dir := dirname; dir != "."; dir = path.Dir(dir) {
+		gpgIDs, err := store.loadGPGIDs(dir)
+		if err == nil {
+			if len(gpgIDs) == 0 {
+				return nil, directoryError(dir, ErrNotInitialized)
+			}
+			return gpgIDs, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("could not read the .gpg-id of \"%s\": %w", dir, err)
+		}
 	}
 
-	encryptedPassword, err := ioutil.ReadFile(passwordPath)
+	gpgIDs := store.GPGIDs
+	if len(store.SigningKeys) > 0 {
+		// store.GPGIDs may have been read before the signing keys were set.
+		var err error
+		if gpgIDs, err = store.loadGPGIDs("."); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("could not read the .gpg-id of the store: %w", err)
+		}
+	}
+
+	if len(gpgIDs) == 0 {
+		return nil, ErrNotInitialized
+	}
+
+	return gpgIDs, nil
+}
+
+// GPGIDsForPassword returns the GPG ids used to encrypt a password.
+func (store *PasswordStore) GPGIDsForPassword(pwname string) ([]string, error) {
+	pwname, _, err := store.passwordPath(pwname)
 	if err != nil {
-		return fmt.Errorf("could not read encrypted password: %w", err)
+		return nil, err
 	}
 
-	decryptedPassword, err := store.GPGBackend.Decrypt(encryptedPassword)
+	return store.GPGIDsForDirectory(path.Dir(pwname))
+}
+
+// ReencryptPassword will reencrypt a password to the GPG ids of its directory
+func (store *PasswordStore) ReencryptPassword(pwname string) error {
+	return store.ReencryptPasswordContext(context.Background(), pwname)
+}
+
+// ReencryptPasswordContext is like ReencryptPassword but stops when ctx is done.
+func (store *PasswordStore) ReencryptPasswordContext(ctx context.Context, pwname string) error {
+	pwname, passwordPath, err := store.findPassword(pwname)
 	if err != nil {
-		return fmt.Errorf("could not decrypt the password: %w", err)
+		return err
 	}
 
-	reEncryptedPassword, err := store.GPGBackend.Encrypt(decryptedPassword, store.GPGIDs)
+	gpgIDs, err := store.GPGIDsForPassword(pwname)
 	if err != nil {
-		return fmt.Errorf("could not re-encrypt the password: %w", err)
+		return err
 	}
 
-	if err := ioutil.WriteFile(passwordPath, reEncryptedPassword, 0600); err != nil {
-		return fmt.Errorf("could not write the newly encrypted password: %w", err)
+	if err := store.reencryptFile(ctx, passwordPath, passwordPath, gpgIDs); err != nil {
+		return passwordError(pwname, err)
 	}
 
 	return nil
@@ -182,210 +417,346 @@ func (store *PasswordStore) ReencryptPassword(pwname string) error {
 
 // InsertPassword inserts a new password or overwrites an existing one
 func (store *PasswordStore) InsertPassword(pwname, pwtext string) error {
-	containsPassword, passwordPath := store.ContainsPassword(pwname)
+	return store.InsertPasswordContext(context.Background(), pwname, pwtext)
+}
+
+// InsertPasswordContext is like InsertPassword but stops when ctx is done.
+func (store *PasswordStore) InsertPasswordContext(ctx context.Context, pwname, pwtext string) error {
+	pwname, passwordPath, err := store.passwordPath(pwname)
+	if err != nil {
+		return err
+	}
 
 	// Check if password already exists
 	var gitAction string
-	if containsPassword {
+	if _, err := store.Storage.Stat(passwordPath); err == nil {
 		gitAction = "edited"
-	} else {
+	} else if os.IsNotExist(err) {
 		gitAction = "added"
+	} else {
+		return passwordError(pwname, err)
+	}
+
+	gpgIDs, err := store.GPGIDsForPassword(pwname)
+	if err != nil {
+		return err
 	}
 
-	encryptedPassword, err := store.GPGBackend.Encrypt([]byte(pwtext), store.GPGIDs)
+	encryptedPassword, err := store.encrypt(ctx, []byte(pwtext), gpgIDs)
 	if err != nil {
-		return fmt.Errorf("could not encrypt the password: %w", err)
+		return passwordError(pwname, err)
+	}
+
+	if err := store.Storage.MkdirAll(path.Dir(passwordPath), 0700); err != nil {
+		return fmt.Errorf("could not create the directory of the password: %w", err)
 	}
 
-	if err := ioutil.WriteFile(passwordPath, encryptedPassword, 0600); err != nil {
+	if err := store.Storage.WriteFile(passwordPath, encryptedPassword, 0600); err != nil {
 		return fmt.Errorf("could not write the newly encrypted password: %w", err)
 	}
 
-	store.AddAndCommit(
+	return store.AddAndCommitContext(ctx,
 		fmt.Sprintf("%s password \"%s\"", gitAction, pwname),
 		passwordPath)
-
-	return nil
 }
 
 // RemoveDirectory removes the directory at the given path
 func (store *PasswordStore) RemoveDirectory(dirname string) error {
-	containsDirectory, directoryPath := store.ContainsDirectory(dirname)
+	return store.RemoveDirectoryContext(context.Background(), dirname)
+}
 
-	if !containsDirectory {
-		return fmt.Errorf("could not find directory at path \"%s\"", directoryPath)
+// RemoveDirectoryContext is like RemoveDirectory but stops when ctx is done.
+func (store *PasswordStore) RemoveDirectoryContext(ctx context.Context, dirname string) error {
+	dirname, directoryPath, err := store.findDirectory(dirname)
+	if err != nil {
+		return err
 	}
 
-	if err := os.RemoveAll(directoryPath); err != nil {
+	if err := store.Storage.RemoveAll(directoryPath); err != nil {
 		return err
 	}
 
-	store.AddAndCommit(
+	return store.AddAndCommitContext(ctx,
 		fmt.Sprintf("removed directory \"%s\" from the store", dirname),
 		directoryPath)
-
-	return nil
 }
 
 // RemovePassword removes the password at the given path
 func (store *PasswordStore) RemovePassword(pwname string) error {
-	containsPassword, passwordPath := store.ContainsPassword(pwname)
+	return store.RemovePasswordContext(context.Background(), pwname)
+}
 
-	if !containsPassword {
-		return fmt.Errorf("could not find password at path \"%s\"", passwordPath)
+// RemovePasswordContext is like RemovePassword but stops when ctx is done.
+func (store *PasswordStore) RemovePasswordContext(ctx context.Context, pwname string) error {
+	pwname, passwordPath, err := store.findPassword(pwname)
+	if err != nil {
+		return err
 	}
 
-	os.Remove(passwordPath)
+	if err := store.Storage.Remove(passwordPath); err != nil {
+		return err
+	}
 
-	store.AddAndCommit(
+	return store.AddAndCommitContext(ctx,
 		fmt.Sprintf("removed password \"%s\" from the store", pwname),
 		passwordPath)
+}
 
-	return nil
+// MoveDirectory moves a directory from source to dest. Passwords whose GPG
+// ids differ at their destination are reencrypted.
+func (store *PasswordStore) MoveDirectory(source, dest string, opts ...MoveOption) error {
+	return store.MoveDirectoryContext(context.Background(), source, dest, opts...)
+}
+
+// MoveDirectoryContext is like MoveDirectory but stops when ctx is done. If
+// anything fails, the store is left unchanged.
+func (store *PasswordStore) MoveDirectoryContext(ctx context.Context, source, dest string, opts ...MoveOption) error {
+	source, _, err := store.findDirectory(source)
+	if err != nil {
+		return err
+	}
+
+	dest, _, err = store.directoryPath(dest)
+	if err != nil {
+		return err
+	}
+
+	batch := store.NewBatch()
+	batch.MoveDirectory(source, dest, opts...)
+
+	return batch.ApplyContext(ctx, fmt.Sprintf("moved directory \"%s\" to \"%s\"", source, dest))
 }
 
-// MoveDirectory moves a directory from source to dest
-func (store *PasswordStore) MoveDirectory(source, dest string) error {
-	containsDirectory, sourceDirectoryPath := store.ContainsDirectory(source)
-	if !containsDirectory {
-		return fmt.Errorf("could not find directory at path \"%s\"", sourceDirectoryPath)
+// moveDirectory moves a directory without committing it.
+func (store *PasswordStore) moveDirectory(ctx context.Context, source, dest string, options *moveOptions) error {
+	source, sourceDirectoryPath, err := store.findDirectory(source)
+	if err != nil {
+		return err
 	}
 
-	destDirectoryPath := path.Join(store.Path, dest)
+	dest, destDirectoryPath, err := store.directoryPath(dest)
+	if err != nil {
+		return err
+	}
 
-	if err := os.Rename(sourceDirectoryPath, destDirectoryPath); err != nil {
+	sourceGPGIDs, err := store.gpgIDsForPasswordsInDirectory(source)
+	if err != nil {
 		return err
 	}
 
-	store.AddAndCommit(
-		fmt.Sprintf("moved directory \"%s\" to \"%s\"", source, dest),
-		sourceDirectoryPath,
-		destDirectoryPath)
+	if err := store.Storage.Rename(sourceDirectoryPath, destDirectoryPath); err != nil {
+		return err
+	}
+
+	if options.reencrypt {
+		return store.reencryptMovedPasswords(ctx, sourceGPGIDs, dest)
+	}
 
 	return nil
 }
 
-// MovePassword moves a passsword or directory from source to dest.
-func (store *PasswordStore) MovePassword(source, dest string) error {
-	containsPassword, sourcePasswordPath := store.ContainsPassword(source)
+// MovePassword moves a passsword or directory from source to dest. The
+// password is reencrypted if its GPG ids differ at its destination.
+func (store *PasswordStore) MovePassword(source, dest string, opts ...MoveOption) error {
+	return store.MovePasswordContext(context.Background(), source, dest, opts...)
+}
+
+// MovePasswordContext is like MovePassword but stops when ctx is done.
+func (store *PasswordStore) MovePasswordContext(ctx context.Context, source, dest string, opts ...MoveOption) error {
+	options := newMoveOptions(opts)
+
+	source, sourcePasswordPath, err := store.findPassword(source)
+	if err != nil {
+		return err
+	}
 
-	if !containsPassword {
-		return fmt.Errorf("could not find password path \"%s\"", sourcePasswordPath)
+	destPasswordName, destPasswordPath, err := store.passwordDestination(source, dest)
+	if err != nil {
+		return err
 	}
 
-	// If the dest ends with a '/', then it is a directory.
-	var destPasswordPath string
-	if strings.HasSuffix(dest, "/") {
-		_, file := filepath.Split(sourcePasswordPath)
-		destPasswordPath = path.Join(store.Path, dest, file)
-	} else {
-		destPasswordPath = path.Join(store.Path, dest+".gpg")
+	reencrypt, destGPGIDs, err := store.needsReencryption(source, destPasswordName, options)
+	if err != nil {
+		return err
 	}
 
-	if err := os.Rename(sourcePasswordPath, destPasswordPath); err != nil {
+	if reencrypt {
+		if err := store.reencryptFile(ctx, sourcePasswordPath, destPasswordPath, destGPGIDs); err != nil {
+			return passwordError(source, err)
+		}
+		if err := store.Storage.Remove(sourcePasswordPath); err != nil {
+			return err
+		}
+	} else if err := store.Storage.Rename(sourcePasswordPath, destPasswordPath); err != nil {
 		return err
 	}
 
-	store.AddAndCommit(
+	return store.AddAndCommitContext(ctx,
 		fmt.Sprintf("moved Password \"%s\" to \"%s\"", source, dest),
 		sourcePasswordPath,
 		destPasswordPath)
+}
 
-	return nil
+// CopyPassword copies a password from source to dest. The copy is
+// reencrypted if its GPG ids differ at its destination.
+func (store *PasswordStore) CopyPassword(source, dest string, opts ...MoveOption) error {
+	return store.CopyPasswordContext(context.Background(), source, dest, opts...)
 }
 
-// CopyPassword copies a password from source to dest
-func (store *PasswordStore) CopyPassword(source, dest string) error {
-	containsPassword, sourcePasswordPath := store.ContainsPassword(source)
+// CopyPasswordContext is like CopyPassword but stops when ctx is done.
+func (store *PasswordStore) CopyPasswordContext(ctx context.Context, source, dest string, opts ...MoveOption) error {
+	options := newMoveOptions(opts)
+
+	source, sourcePasswordPath, err := store.findPassword(source)
+	if err != nil {
+		return err
+	}
 
-	if !containsPassword {
-		return fmt.Errorf("could not find password or at path \"%s\"", sourcePasswordPath)
+	destPasswordName, destPasswordPath, err := store.passwordDestination(source, dest)
+	if err != nil {
+		return err
 	}
 
-	// If the dest ends with a '/', then it is a directory.
-	var destPasswordPath string
-	if strings.HasSuffix(dest, "/") {
-		_, file := filepath.Split(sourcePasswordPath)
-		destPasswordPath = path.Join(store.Path, dest, file)
-	} else {
-		destPasswordPath = path.Join(store.Path, dest+".gpg")
+	reencrypt, destGPGIDs, err := store.needsReencryption(source, destPasswordName, options)
+	if err != nil {
+		return err
 	}
 
-	if err := gopassio.CopyFileContents(sourcePasswordPath, destPasswordPath); err != nil {
+	if reencrypt {
+		if err := store.reencryptFile(ctx, sourcePasswordPath, destPasswordPath, destGPGIDs); err != nil {
+			return passwordError(source, err)
+		}
+	} else if err := store.copyFile(sourcePasswordPath, destPasswordPath); err != nil {
 		return err
 	}
 
-	store.AddAndCommit(
+	return store.AddAndCommitContext(ctx,
 		fmt.Sprintf("copied Password \"%s\" to \"%s\"", source, dest),
 		destPasswordPath)
+}
 
-	return nil
+// CopyDirectory copies a directory from source to dest. Passwords whose GPG
+// ids differ at their destination are reencrypted.
+func (store *PasswordStore) CopyDirectory(source, dest string, opts ...MoveOption) error {
+	return store.CopyDirectoryContext(context.Background(), source, dest, opts...)
+}
+
+// CopyDirectoryContext is like CopyDirectory but stops when ctx is done. If
+// anything fails, the store is left unchanged.
+func (store *PasswordStore) CopyDirectoryContext(ctx context.Context, source, dest string, opts ...MoveOption) error {
+	source, _, err := store.findDirectory(source)
+	if err != nil {
+		return err
+	}
+
+	dest, _, err = store.directoryPath(dest)
+	if err != nil {
+		return err
+	}
+
+	batch := store.NewBatch()
+	batch.CopyDirectory(source, dest, opts...)
+
+	return batch.ApplyContext(ctx, fmt.Sprintf("copied directory \"%s\" to \"%s\"", source, dest))
 }
 
-// CopyDirectory copies a directory from source to dest
-func (store *PasswordStore) CopyDirectory(source, dest string) error {
-	containsDirectory, sourceDirectoryPath := store.ContainsDirectory(source)
+// copyDirectoryTo copies a directory without committing it.
+func (store *PasswordStore) copyDirectoryTo(ctx context.Context, source, dest string, options *moveOptions) error {
+	source, sourceDirectoryPath, err := store.findDirectory(source)
+	if err != nil {
+		return err
+	}
 
-	if !containsDirectory {
-		return fmt.Errorf("could not find directory at path \"%s\"", path.Join(store.Path, source))
+	_, destDirectoryPath, err := store.directoryPath(dest)
+	if err != nil {
+		return err
+	}
+
+	sourceGPGIDs, err := store.gpgIDsForPasswordsInDirectory(source)
+	if err != nil {
+		return err
 	}
 
-	destDirectoryPath := path.Join(store.Path, dest)
-	if err := exec.Command("cp", "-r", sourceDirectoryPath, destDirectoryPath).Run(); err != nil {
+	// The copy may be inside of dest, whose name is also its path.
+	dest, err = store.copyDirectory(sourceDirectoryPath, destDirectoryPath)
+	if err != nil {
 		return err
 	}
 
-	store.AddAndCommit(
-		fmt.Sprintf("copied directory \"%s\" to \"%s\"", source, dest),
-		destDirectoryPath)
+	if options.reencrypt {
+		return store.reencryptMovedPasswords(ctx, sourceGPGIDs, dest)
+	}
 
 	return nil
 }
 
 // GetPassword returns a decrypted password
 func (store *PasswordStore) GetPassword(pwname string) (string, error) {
-	containsPassword, passwordPath := store.ContainsPassword(pwname)
+	return store.GetPasswordContext(context.Background(), pwname)
+}
 
-	// Error if the password does not exist
-	if !containsPassword {
-		return "", fmt.Errorf("could not find password \"%s\" at path \"%s\"", pwname, passwordPath)
+// GetPasswordContext is like GetPassword but stops when ctx is done.
+func (store *PasswordStore) GetPasswordContext(ctx context.Context, pwname string) (string, error) {
+	content, err := store.GetRawPasswordContext(ctx, pwname)
+	if err != nil {
+		return "", err
 	}
+	return strings.TrimSpace(content), nil
+}
+
+// GetRawPassword returns the decrypted content of a password as it is
+// stored, without trimming its whitespace, so that it can be modified and
+// inserted back unchanged.
+func (store *PasswordStore) GetRawPassword(pwname string) (string, error) {
+	return store.GetRawPasswordContext(context.Background(), pwname)
+}
 
-	encryptedPassword, err := ioutil.ReadFile(passwordPath)
+// GetRawPasswordContext is like GetRawPassword but stops when ctx is done.
+func (store *PasswordStore) GetRawPasswordContext(ctx context.Context, pwname string) (string, error) {
+	pwname, passwordPath, err := store.findPassword(pwname)
+	if err != nil {
+		return "", err
+	}
+
+	encryptedPassword, err := store.Storage.ReadFile(passwordPath)
 	if err != nil {
 		return "", fmt.Errorf("could not read the encrypted password: %w", err)
 	}
 
-	decryptedPassword, err := store.GPGBackend.Decrypt(encryptedPassword)
+	decryptedPassword, err := store.decrypt(ctx, encryptedPassword)
 	if err != nil {
-		return "", fmt.Errorf("could not decrypt the password: %w", err)
+		return "", passwordError(pwname, err)
 	}
 
-	return strings.TrimSpace(string(decryptedPassword)), nil
+	return string(decryptedPassword), nil
 }
 
 // ContainsPassword returns whether or not the store contains a password with this name.
 // it also conveniently returns the password path that was checked
 func (store *PasswordStore) ContainsPassword(pwname string) (bool, string) {
-	passwordPath := path.Join(store.Path, pwname+".gpg")
-
-	if _, err := os.Stat(passwordPath); os.IsNotExist(err) {
-		return false, passwordPath
+	_, passwordPath, err := store.findPassword(pwname)
+	if err != nil {
+		return false, ""
 	}
-
-	return true, passwordPath
+	return true, path.Join(store.Path, passwordPath)
 }
 
 // ContainsDirectory returns whether or not the store contains a directory with this name.
 // it also conveniently returns the directory path that was checked
 func (store *PasswordStore) ContainsDirectory(dirname string) (bool, string) {
-	directoryPath := path.Join(store.Path, dirname)
-
-	if _, err := os.Stat(directoryPath); os.IsNotExist(err) {
-		return false, directoryPath
+	_, directoryPath, err := store.findDirectory(dirname)
+	if err != nil {
+		return false, ""
 	}
+	return true, path.Join(store.Path, directoryPath)
+}
 
-	return true, directoryPath
+// FindDirectory returns the canonical name of a directory. It fails with
+// ErrInvalidName if the name is not allowed, or with ErrNotFound if the
+// directory does not exist.
+func (store *PasswordStore) FindDirectory(dirname string) (string, error) {
+	cleaned, _, err := store.findDirectory(dirname)
+	return cleaned, err
 }
 
 // GetPasswordsList returns a list of all the passwords
@@ -394,56 +765,37 @@ func (store *PasswordStore) GetPasswordsList() []string {
 
 	var scan = func(path string, fileInfo os.FileInfo, inpErr error) (err error) {
 		if strings.HasSuffix(path, ".gpg") {
-			password := strings.TrimSuffix(
-				strings.TrimPrefix(path, store.Path+"/"),
-				".gpg",
-			)
-			list = append(list, password)
+			list = append(list, strings.TrimSuffix(path, ".gpg"))
 		}
 		return
 	}
 
-	filepath.Walk(store.Path, scan)
+	store.Storage.Walk(".", scan)
 
 	return list
 }
 
-// AddAndCommit adds paths to the index and creates a commit
+// AddAndCommit adds paths to the index and creates a commit. Paths are
+// relative to the root of the store.
 func (store *PasswordStore) AddAndCommit(message string, paths ...string) error {
-	store.git("reset")
-
-	for _, path := range paths {
-		store.git("add", path)
-	}
-
-	store.git("commit", "-m", message)
-
-	return nil
+	return store.AddAndCommitContext(context.Background(), message, paths...)
 }
 
-// git executes a git command
-func (store *PasswordStore) git(args ...string) error {
-	if !store.UsesGit {
-		return nil
-	}
+// AddAndCommitContext is like AddAndCommit but stops when ctx is done.
+func (store *PasswordStore) AddAndCommitContext(ctx context.Context, message string, paths ...string) error {
+	vcs := store.vcs()
 
-	gitArgs := []string{
-		"--git-dir=" + store.GitDir,
-		"--work-tree=" + store.Path}
-
-	gitArgs = append(gitArgs, args...)
-
-	git := exec.Command("git", gitArgs...)
+	if err := vcs.Add(ctx, paths...); err != nil {
+		return err
+	}
 
-	// Should we do that?
-	git.Stdout = os.Stdout
-	git.Stderr = os.Stderr
-	git.Stdin = os.Stdin
+	return vcs.Commit(ctx, message)
+}
 
-	if err := git.Run(); err != nil {
-		fmt.Println(err.Error())
-		return fmt.Errorf("git error: \"%s\"", err.Error())
+// vcs returns the VCS of the store, or a NoopVCS if it does not use one.
+func (store *PasswordStore) vcs() VCS {
+	if !store.UsesGit || store.VCS == nil {
+		return NoopVCS{}
 	}
-
-	return nil
+	return store.VCS
 }
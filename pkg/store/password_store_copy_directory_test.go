@@ -0,0 +1,77 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package store_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCopyDirectory(t *testing.T) {
+	passwordStore, _ := newRecordingPasswordStore(t)
+
+	if err := os.MkdirAll(filepath.Join(passwordStore.Path, "dir", "sub"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, pwname := range []string{"dir/a.com", "dir/sub/b.com"} {
+		if err := passwordStore.InsertPassword(pwname, "password"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := passwordStore.CopyDirectory("dir", "copy"); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(
+		t,
+		[]string{"copy/a.com", "copy/sub/b.com", "dir/a.com", "dir/sub/b.com"},
+		passwordStore.GetPasswordsList(),
+	)
+
+	fileInfo, err := os.Stat(filepath.Join(passwordStore.Path, "copy", "sub", "b.com.gpg"))
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), fileInfo.Mode().Perm())
+}
+
+func TestCopyDirectoryIntoExistingDirectory(t *testing.T) {
+	passwordStore, storage := newTaggingPasswordStore(t, "dir/test.com")
+
+	if err := passwordStore.CopyDirectory("dir", "ops"); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"dir/test.com", "ops/dir/test.com"}, passwordStore.GetPasswordsList())
+	assert.Equal(t, "ops", recipients(t, storage, "ops/dir/test.com"))
+}
+
+func TestCopyDirectoryFailureLeavesStoreUnchanged(t *testing.T) {
+	passwordStore, storage := newTaggingPasswordStore(t, "dir/a.com", "dir/bad.com")
+
+	err := passwordStore.CopyDirectory("dir", "ops/dir")
+	assert.NotNil(t, err)
+
+	assert.Equal(t, []string{"dir/a.com", "dir/bad.com"}, passwordStore.GetPasswordsList())
+
+	_, err = storage.Stat("ops/dir")
+	assert.True(t, os.IsNotExist(err), "ops/dir should have been removed")
+}
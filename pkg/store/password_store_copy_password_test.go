@@ -75,3 +75,45 @@ func TestCopyPasswordInDirectory(t *testing.T) {
 	_, err = os.Stat(destPasswordPath)
 	assert.Nil(t, err, "test.com.gpg shoudl have been copied to dir/test2.com.gpg")
 }
+
+func TestCopyPasswordReencryptsToDestinationGPGIDs(t *testing.T) {
+	passwordStore, backend := newRecordingPasswordStore(t)
+
+	if err := passwordStore.SetDirectoryGPGIDs("team", []string{"team"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := passwordStore.InsertPassword("test.com", "password"); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"root"}, backend.recipients)
+
+	if err := passwordStore.CopyPassword("test.com", "team/test.com"); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"team"}, backend.recipients)
+
+	for _, password := range []string{"test.com", "team/test.com"} {
+		decryptedPassword, err := passwordStore.GetPassword(password)
+		assert.Nil(t, err)
+		assert.Equal(t, "password", decryptedPassword)
+	}
+}
+
+func TestCopyPasswordSameGPGIDsIsNotReencrypted(t *testing.T) {
+	passwordStore, backend := newRecordingPasswordStore(t)
+
+	if err := passwordStore.SetDirectoryGPGIDs("team", []string{"root"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := passwordStore.InsertPassword("test.com", "password"); err != nil {
+		t.Fatal(err)
+	}
+	backend.recipients = nil
+
+	if err := passwordStore.CopyPassword("test.com", "team/test.com"); err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, backend.recipients, "the password should not have been reencrypted")
+}
@@ -0,0 +1,143 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package store_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aviau/gopass/pkg/store"
+)
+
+// recordingGPGBackend is a GPGBackend that does not encrypt anything but
+// records the recipients it was asked to encrypt to.
+type recordingGPGBackend struct {
+	mu         sync.Mutex
+	recipients []string
+}
+
+func (backend *recordingGPGBackend) Encrypt(content []byte, recipients []string) ([]byte, error) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	backend.recipients = recipients
+	return content, nil
+}
+
+func (backend *recordingGPGBackend) Decrypt(content []byte) ([]byte, error) {
+	return content, nil
+}
+
+func newRecordingPasswordStore(t *testing.T) (*store.PasswordStore, *recordingGPGBackend) {
+	backend := &recordingGPGBackend{}
+
+	passwordStore := store.NewPasswordStore(t.TempDir())
+	passwordStore.UsesGit = false
+	passwordStore.GPGBackend = backend
+
+	if err := passwordStore.Init([]string{"root"}); err != nil {
+		t.Fatal(err)
+	}
+
+	return passwordStore, backend
+}
+
+func TestGPGIDsForPasswordNearestGPGIDFile(t *testing.T) {
+	passwordStore, _ := newRecordingPasswordStore(t)
+
+	if err := passwordStore.SetDirectoryGPGIDs("ops", []string{"ops1", "ops2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	gpgIDs, err := passwordStore.GPGIDsForPassword("test.com")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"root"}, gpgIDs)
+
+	gpgIDs, err = passwordStore.GPGIDsForPassword("ops/test.com")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ops1", "ops2"}, gpgIDs)
+
+	gpgIDs, err = passwordStore.GPGIDsForPassword("ops/servers/test.com")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ops1", "ops2"}, gpgIDs)
+
+	gpgIDs, err = passwordStore.GPGIDsForDirectory("ops")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ops1", "ops2"}, gpgIDs)
+}
+
+func TestSetDirectoryGPGIDs(t *testing.T) {
+	passwordStore, _ := newRecordingPasswordStore(t)
+
+	if err := passwordStore.SetDirectoryGPGIDs("finance/team", []string{"finance"}); err != nil {
+		t.Fatal(err)
+	}
+
+	gpgIDContent, err := ioutil.ReadFile(filepath.Join(passwordStore.Path, "finance", "team", ".gpg-id"))
+	assert.Nil(t, err)
+	assert.Equal(t, "finance\n", string(gpgIDContent))
+
+	rootGPGIDContent, err := ioutil.ReadFile(filepath.Join(passwordStore.Path, ".gpg-id"))
+	assert.Nil(t, err)
+	assert.Equal(t, "root\n", string(rootGPGIDContent))
+	assert.Equal(t, []string{"root"}, passwordStore.GPGIDs)
+}
+
+func TestInsertPasswordUsesDirectoryGPGIDs(t *testing.T) {
+	passwordStore, backend := newRecordingPasswordStore(t)
+
+	if err := passwordStore.SetDirectoryGPGIDs("ops", []string{"ops"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := passwordStore.InsertPassword("test.com", "root password"); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"root"}, backend.recipients)
+
+	if err := passwordStore.InsertPassword("ops/test.com", "ops password"); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"ops"}, backend.recipients)
+}
+
+func TestReencryptPasswordUsesDirectoryGPGIDs(t *testing.T) {
+	passwordStore, backend := newRecordingPasswordStore(t)
+
+	if err := os.Mkdir(filepath.Join(passwordStore.Path, "ops"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := passwordStore.InsertPassword("ops/test.com", "ops password"); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"root"}, backend.recipients)
+
+	if err := passwordStore.SetDirectoryGPGIDs("ops", []string{"ops"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := passwordStore.ReencryptPassword("ops/test.com"); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"ops"}, backend.recipients)
+}
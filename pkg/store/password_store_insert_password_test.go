@@ -0,0 +1,58 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package store_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsertPasswordCreatesDirectories(t *testing.T) {
+	passwordStore, _ := newRecordingPasswordStore(t)
+
+	if err := passwordStore.InsertPassword("dir/subdir/test.com", "password"); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(filepath.Join(passwordStore.Path, "dir", "subdir"))
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+
+	password, err := passwordStore.GetPassword("dir/subdir/test.com")
+	assert.Nil(t, err)
+	assert.Equal(t, "password", password)
+}
+
+func TestGetRawPassword(t *testing.T) {
+	passwordStore, _ := newRecordingPasswordStore(t)
+
+	if err := passwordStore.InsertPassword("test.com", "  password \nnotes\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := passwordStore.GetRawPassword("test.com")
+	assert.Nil(t, err)
+	assert.Equal(t, "  password \nnotes\n", content)
+
+	password, err := passwordStore.GetPassword("test.com")
+	assert.Nil(t, err)
+	assert.Equal(t, "password \nnotes", password)
+}
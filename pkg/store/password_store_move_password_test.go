@@ -25,6 +25,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/aviau/gopass/internal/storetest"
+	"github.com/aviau/gopass/pkg/store"
 )
 
 func TestMovePassword(t *testing.T) {
@@ -87,3 +88,86 @@ func TestMovePasswordInDirectory(t *testing.T) {
 	_, err = os.Stat(destinationPath)
 	assert.Nil(t, err, "dir/test.com.gpg should now exist")
 }
+
+func TestMovePasswordReencryptsToDestinationGPGIDs(t *testing.T) {
+	passwordStore, backend := newRecordingPasswordStore(t)
+
+	if err := passwordStore.SetDirectoryGPGIDs("team", []string{"team"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := passwordStore.InsertPassword("test.com", "password"); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"root"}, backend.recipients)
+
+	if err := passwordStore.MovePassword("test.com", "team/"); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"team"}, backend.recipients)
+
+	decryptedPassword, err := passwordStore.GetPassword("team/test.com")
+	assert.Nil(t, err)
+	assert.Equal(t, "password", decryptedPassword)
+
+	containsPassword, _ := passwordStore.ContainsPassword("test.com")
+	assert.False(t, containsPassword, "test.com should no longer exist")
+}
+
+func TestMovePasswordWithoutReencrypt(t *testing.T) {
+	passwordStore, backend := newRecordingPasswordStore(t)
+
+	if err := passwordStore.SetDirectoryGPGIDs("team", []string{"team"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := passwordStore.InsertPassword("test.com", "password"); err != nil {
+		t.Fatal(err)
+	}
+	backend.recipients = nil
+
+	if err := passwordStore.MovePassword("test.com", "team/test.com", store.WithReencrypt(false)); err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, backend.recipients, "the password should not have been reencrypted")
+
+	containsPassword, _ := passwordStore.ContainsPassword("team/test.com")
+	assert.True(t, containsPassword, "team/test.com should now exist")
+}
+
+func TestMoveDirectoryReencryptsToDestinationGPGIDs(t *testing.T) {
+	passwordStore, backend := newRecordingPasswordStore(t)
+
+	if err := passwordStore.SetDirectoryGPGIDs("team", []string{"team"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Mkdir(filepath.Join(passwordStore.Path, "personal"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := passwordStore.InsertPassword("personal/test.com", "password"); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"root"}, backend.recipients)
+
+	if err := passwordStore.MoveDirectory("personal", "team/personal"); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"team"}, backend.recipients)
+
+	decryptedPassword, err := passwordStore.GetPassword("team/personal/test.com")
+	assert.Nil(t, err)
+	assert.Equal(t, "password", decryptedPassword)
+}
+
+func TestMoveDirectoryFailureLeavesStoreUnchanged(t *testing.T) {
+	passwordStore, storage := newTaggingPasswordStore(t, "dir/a.com", "dir/bad.com")
+	rootRecipients := recipients(t, storage, "dir/a.com")
+
+	err := passwordStore.MoveDirectory("dir", "ops/dir")
+	assert.NotNil(t, err)
+
+	assert.Equal(t, []string{"dir/a.com", "dir/bad.com"}, passwordStore.GetPasswordsList())
+	assert.Equal(t, rootRecipients, recipients(t, storage, "dir/a.com"))
+}
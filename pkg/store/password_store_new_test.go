@@ -18,6 +18,8 @@
 package store_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -29,3 +31,21 @@ func TestNewPasswordStoreUsesGit(t *testing.T) {
 	passwordStore := store.NewPasswordStore(t.TempDir())
 	assert.True(t, passwordStore.UsesGit, "UsesGit should be true by default")
 }
+
+func TestNewPasswordStoreWithoutGit(t *testing.T) {
+	storePath := t.TempDir()
+	if err := os.WriteFile(filepath.Join(storePath, ".gpg-id"), []byte("root\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// As made by "pass init" without "pass git init".
+	passwordStore := store.NewPasswordStore(storePath)
+	assert.False(t, passwordStore.UsesGit)
+
+	if err := os.Mkdir(filepath.Join(storePath, ".git"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	passwordStore = store.NewPasswordStore(storePath)
+	assert.True(t, passwordStore.UsesGit)
+}
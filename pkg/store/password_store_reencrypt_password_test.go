@@ -0,0 +1,115 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package store_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aviau/gopass/pkg/store"
+)
+
+// corruptingGPGBackend is a GPGBackend whose ciphertexts can't be decrypted
+// once corrupt is set.
+type corruptingGPGBackend struct {
+	corrupt bool
+}
+
+func (backend *corruptingGPGBackend) Encrypt(content []byte, recipients []string) ([]byte, error) {
+	if backend.corrupt {
+		return []byte("corrupted"), nil
+	}
+	return append([]byte("encrypted:"), content...), nil
+}
+
+func (backend *corruptingGPGBackend) Decrypt(content []byte) ([]byte, error) {
+	if !bytes.HasPrefix(content, []byte("encrypted:")) {
+		return nil, errors.New("invalid ciphertext")
+	}
+	return bytes.TrimPrefix(content, []byte("encrypted:")), nil
+}
+
+func TestReencryptPasswordVerifiesCiphertext(t *testing.T) {
+	passwordStore, _ := newRecordingPasswordStore(t)
+
+	backend := &corruptingGPGBackend{}
+	passwordStore.GPGBackend = backend
+
+	if err := passwordStore.InsertPassword("test.com", "password"); err != nil {
+		t.Fatal(err)
+	}
+
+	backend.corrupt = true
+
+	err := passwordStore.ReencryptPassword("test.com")
+	assert.True(t, errors.Is(err, store.ErrDecrypt))
+
+	backend.corrupt = false
+
+	decryptedPassword, err := passwordStore.GetPassword("test.com")
+	assert.Nil(t, err)
+	assert.Equal(t, "password", decryptedPassword, "the previous ciphertext should have been kept")
+}
+
+// operatorGPGBackend is a keyIDsGPGBackend for an operator who only has the
+// secret key of "root". It drops the "dropped" recipient when encrypting.
+type operatorGPGBackend struct {
+	keyIDsGPGBackend
+}
+
+func (backend *operatorGPGBackend) Encrypt(content []byte, recipients []string) ([]byte, error) {
+	var kept []string
+	for _, recipient := range recipients {
+		if recipient != "dropped" {
+			kept = append(kept, recipient)
+		}
+	}
+	return backend.keyIDsGPGBackend.Encrypt(content, kept)
+}
+
+func (backend *operatorGPGBackend) Decrypt(content []byte) ([]byte, error) {
+	recipients := strings.Split(string(content[:bytes.IndexByte(content, '\n')]), ",")
+	for _, recipient := range recipients {
+		if recipient == "root" {
+			return backend.keyIDsGPGBackend.Decrypt(content)
+		}
+	}
+	return nil, errors.New("no secret key")
+}
+
+func TestReencryptPasswordForOtherRecipients(t *testing.T) {
+	passwordStore, storage := newTaggingPasswordStore(t, "test.com")
+	passwordStore.GPGBackend = &operatorGPGBackend{}
+
+	assert.Nil(t, passwordStore.SetGPGIDs([]string{"colleague"}))
+	assert.Equal(t, "colleague", recipients(t, storage, "test.com"))
+}
+
+func TestReencryptPasswordForOtherRecipientsChecksRecipients(t *testing.T) {
+	passwordStore, storage := newTaggingPasswordStore(t, "test.com")
+	passwordStore.GPGBackend = &operatorGPGBackend{}
+
+	err := passwordStore.SetGPGIDs([]string{"colleague", "dropped"})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "not encrypted to dropped")
+	assert.Equal(t, "root", recipients(t, storage, "test.com"))
+}
@@ -0,0 +1,234 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Recipient is a key that a password is encrypted to.
+type Recipient struct {
+	KeyID   string   // The long key ID, in hexadecimal
+	UserIDs []string // The user IDs of the key, empty if it is not in the keyring
+}
+
+// PasswordRecipients lists the keys that a password is encrypted to.
+type PasswordRecipients struct {
+	Password   string
+	Recipients []Recipient
+}
+
+// Recipients returns the keys that a password, or each password of a
+// directory, is actually encrypted to. An empty name is the root of the
+// store. It fails with ErrUnsupported if the GPG backend is not a
+// RecipientsGPGBackend.
+func (store *PasswordStore) Recipients(name string) ([]PasswordRecipients, error) {
+	return store.RecipientsContext(context.Background(), name)
+}
+
+// RecipientsContext is like Recipients but stops when ctx is done.
+func (store *PasswordStore) RecipientsContext(ctx context.Context, name string) ([]PasswordRecipients, error) {
+	backend, ok := store.GPGBackend.(RecipientsGPGBackend)
+	if !ok {
+		return nil, ErrUnsupported
+	}
+
+	passwords, err := store.passwordsIn(name)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make(map[string][]string)
+	var result []PasswordRecipients
+
+	for _, password := range passwords {
+		encryptedPassword, err := store.Storage.ReadFile(password + ".gpg")
+		if err != nil {
+			return nil, passwordError(password, err)
+		}
+
+		keyIDs, err := backend.Recipients(ctx, encryptedPassword)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		} else if err != nil {
+			return nil, passwordError(password, backendError(ErrDecrypt, err))
+		}
+
+		passwordRecipients := PasswordRecipients{Password: password}
+		for _, keyID := range keyIDs {
+			keyID = strings.ToUpper(keyID)
+			ids, ok := userIDs[keyID]
+			if !ok {
+				if ids, err = backend.UserIDs(ctx, keyID); err != nil {
+					return nil, err
+				}
+				userIDs[keyID] = ids
+			}
+			passwordRecipients.Recipients = append(passwordRecipients.Recipients, Recipient{KeyID: keyID, UserIDs: ids})
+		}
+
+		result = append(result, passwordRecipients)
+	}
+
+	return result, nil
+}
+
+// passwordsIn returns the password called name, or the passwords of the
+// directory called name.
+func (store *PasswordStore) passwordsIn(name string) ([]string, error) {
+	if isRootName(name) {
+		return store.GetPasswordsList(), nil
+	}
+
+	if !strings.HasSuffix(name, "/") {
+		pwname, _, err := store.findPassword(name)
+		if err == nil {
+			return []string{pwname}, nil
+		} else if !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	dirname, _, err := store.findDirectory(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var passwords []string
+	for _, password := range store.GetPasswordsList() {
+		if strings.HasPrefix(password, dirname+"/") {
+			passwords = append(passwords, password)
+		}
+	}
+
+	return passwords, nil
+}
+
+// AddGPGID adds a GPG id to the .gpg-id file of a directory, an empty name
+// being the root of the store, and reencrypts the passwords that use it. If
+// the directory has no .gpg-id file, one is created from the GPG ids it
+// inherits, so that only the directory is affected. It fails with
+// ErrUnknownKey if the GPG backend is a RecipientsGPGBackend and the key is
+// not in the keyring.
+func (store *PasswordStore) AddGPGID(dirname, gpgID string, opts ...ReencryptOption) error {
+	return store.AddGPGIDContext(context.Background(), dirname, gpgID, opts...)
+}
+
+// AddGPGIDContext is like AddGPGID but stops when ctx is done.
+func (store *PasswordStore) AddGPGIDContext(ctx context.Context, dirname, gpgID string, opts ...ReencryptOption) error {
+	if backend, ok := store.GPGBackend.(RecipientsGPGBackend); ok {
+		keyIDs, err := backend.KeyIDs(ctx, gpgID)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		} else if err != nil || len(keyIDs) == 0 {
+			return fmt.Errorf("%w: %s", ErrUnknownKey, gpgID)
+		}
+	}
+
+	dirname, gpgIDs, err := store.directoryGPGIDs(dirname)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range gpgIDs {
+		if id == gpgID {
+			return directoryError(dirname, fmt.Errorf("%w: GPG id %s", ErrAlreadyExists, gpgID))
+		}
+	}
+
+	message := fmt.Sprintf("Add GPG id %s", gpgID)
+	if dirname != "." {
+		message += fmt.Sprintf(" to \"%s\"", dirname)
+	}
+
+	return store.replaceGPGIDs(ctx, dirname, append(append([]string{}, gpgIDs...), gpgID), message, opts)
+}
+
+// RemoveGPGID removes a GPG id from the .gpg-id file of a directory, an
+// empty name being the root of the store, and reencrypts the passwords that
+// use it. Like AddGPGID, the .gpg-id file is created if needed. The last GPG
+// id of a directory can't be removed.
+func (store *PasswordStore) RemoveGPGID(dirname, gpgID string, opts ...ReencryptOption) error {
+	return store.RemoveGPGIDContext(context.Background(), dirname, gpgID, opts...)
+}
+
+// RemoveGPGIDContext is like RemoveGPGID but stops when ctx is done.
+func (store *PasswordStore) RemoveGPGIDContext(ctx context.Context, dirname, gpgID string, opts ...ReencryptOption) error {
+	dirname, gpgIDs, err := store.directoryGPGIDs(dirname)
+	if err != nil {
+		return err
+	}
+
+	var remaining []string
+	for _, id := range gpgIDs {
+		if id != gpgID {
+			remaining = append(remaining, id)
+		}
+	}
+
+	if len(remaining) == len(gpgIDs) {
+		return directoryError(dirname, fmt.Errorf("%w: GPG id %s", ErrNotFound, gpgID))
+	}
+	if len(remaining) == 0 {
+		return directoryError(dirname, fmt.Errorf("can't remove the last GPG id %s", gpgID))
+	}
+
+	message := fmt.Sprintf("Remove GPG id %s", gpgID)
+	if dirname != "." {
+		message += fmt.Sprintf(" from \"%s\"", dirname)
+	}
+
+	return store.replaceGPGIDs(ctx, dirname, remaining, message, opts)
+}
+
+// directoryGPGIDs returns the canonical name of a directory, "." being the root of
+// the store, and the GPG ids that currently apply to it.
+func (store *PasswordStore) directoryGPGIDs(dirname string) (string, []string, error) {
+	if isRootName(dirname) {
+		dirname = "."
+	} else {
+		var err error
+		if dirname, _, err = store.findDirectory(dirname); err != nil {
+			return "", nil, err
+		}
+	}
+
+	gpgIDs, err := store.GPGIDsForDirectory(dirname)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return dirname, gpgIDs, nil
+}
+
+// replaceGPGIDs rewrites the .gpg-id file of a directory and reencrypts the
+// passwords that use it in a single commit.
+func (store *PasswordStore) replaceGPGIDs(ctx context.Context, dirname string, gpgIDs []string, message string, opts []ReencryptOption) error {
+	batch := store.NewBatch()
+
+	if dirname == "." {
+		batch.SetGPGIDs(gpgIDs, opts...)
+	} else {
+		batch.SetDirectoryGPGIDs(dirname, gpgIDs, opts...)
+	}
+
+	return batch.ApplyContext(ctx, message)
+}
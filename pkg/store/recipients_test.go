@@ -0,0 +1,173 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package store_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aviau/gopass/pkg/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecipientsPassword(t *testing.T) {
+	passwordStore, _ := newTaggingPasswordStore(t, "test.com", "ops/test.com")
+	passwordStore.GPGBackend = &keyIDsGPGBackend{}
+
+	recipients, err := passwordStore.Recipients("test.com")
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		[]store.PasswordRecipients{
+			{
+				Password: "test.com",
+				Recipients: []store.Recipient{
+					{KeyID: "ROOT", UserIDs: []string{"Root <root@example.com>"}},
+				},
+			},
+		},
+		recipients,
+	)
+}
+
+func TestRecipientsDirectory(t *testing.T) {
+	passwordStore, _ := newTaggingPasswordStore(t, "test.com", "ops/a.com", "ops/b.com")
+	passwordStore.GPGBackend = &keyIDsGPGBackend{}
+
+	recipients, err := passwordStore.Recipients("ops/")
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		[]store.PasswordRecipients{
+			{Password: "ops/a.com", Recipients: []store.Recipient{{KeyID: "OPS"}}},
+			{Password: "ops/b.com", Recipients: []store.Recipient{{KeyID: "OPS"}}},
+		},
+		recipients,
+	)
+
+	recipients, err = passwordStore.Recipients("")
+	assert.Nil(t, err)
+	assert.Len(t, recipients, 3)
+}
+
+func TestRecipientsNotFound(t *testing.T) {
+	passwordStore, _ := newTaggingPasswordStore(t)
+	passwordStore.GPGBackend = &keyIDsGPGBackend{}
+
+	_, err := passwordStore.Recipients("missing.com")
+	assert.True(t, errors.Is(err, store.ErrNotFound))
+}
+
+func TestRecipientsUnsupported(t *testing.T) {
+	passwordStore, _ := newTaggingPasswordStore(t, "test.com")
+
+	_, err := passwordStore.Recipients("test.com")
+	assert.True(t, errors.Is(err, store.ErrUnsupported))
+}
+
+func TestAddGPGID(t *testing.T) {
+	passwordStore, storage := newTaggingPasswordStore(t, "test.com", "ops/test.com")
+	passwordStore.GPGBackend = &keyIDsGPGBackend{}
+
+	if err := storage.MkdirAll("ops/servers", 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	err := passwordStore.AddGPGID("ops/servers", "alice")
+	assert.Nil(t, err)
+
+	gpgIDs, err := passwordStore.GPGIDsForDirectory("ops/servers")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ops", "alice"}, gpgIDs)
+
+	gpgIDs, err = passwordStore.GPGIDsForDirectory("ops")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ops"}, gpgIDs, "only the directory should be affected")
+
+	if err := passwordStore.InsertPassword("ops/servers/test.com", "password"); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "ops,alice", recipients(t, storage, "ops/servers/test.com"))
+
+	err = passwordStore.AddGPGID("ops", "alice")
+	assert.Nil(t, err)
+	assert.Equal(t, "ops,alice", recipients(t, storage, "ops/test.com"))
+	assert.Equal(t, "root", recipients(t, storage, "test.com"))
+
+	err = passwordStore.AddGPGID("", "alice")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"root", "alice"}, passwordStore.GPGIDs)
+	assert.Equal(t, "root,alice", recipients(t, storage, "test.com"))
+}
+
+func TestAddGPGIDErrors(t *testing.T) {
+	passwordStore, _ := newTaggingPasswordStore(t, "test.com")
+	passwordStore.GPGBackend = &keyIDsGPGBackend{}
+
+	err := passwordStore.AddGPGID("", "root")
+	assert.True(t, errors.Is(err, store.ErrAlreadyExists))
+
+	err = passwordStore.AddGPGID("", "unknown")
+	assert.True(t, errors.Is(err, store.ErrUnknownKey))
+
+	err = passwordStore.AddGPGID("missing", "alice")
+	assert.True(t, errors.Is(err, store.ErrNotFound))
+}
+
+func TestRemoveGPGID(t *testing.T) {
+	passwordStore, storage := newTaggingPasswordStore(t, "ops/test.com")
+	passwordStore.GPGBackend = &keyIDsGPGBackend{}
+
+	if err := passwordStore.SetDirectoryGPGIDs("ops", []string{"ops", "alice"}); err != nil {
+		t.Fatal(err)
+	}
+
+	err := passwordStore.RemoveGPGID("ops", "alice")
+	assert.Nil(t, err)
+	assert.Equal(t, "ops", recipients(t, storage, "ops/test.com"))
+
+	err = passwordStore.RemoveGPGID("ops", "alice")
+	assert.True(t, errors.Is(err, store.ErrNotFound))
+
+	err = passwordStore.RemoveGPGID("ops", "ops")
+	assert.NotNil(t, err, "the last GPG id should not be removed")
+	assert.Equal(t, "ops", recipients(t, storage, "ops/test.com"))
+}
+
+func TestGPGIDCommitMessages(t *testing.T) {
+	passwordStore, _ := newGitPasswordStore(t)
+
+	if err := os.Mkdir(filepath.Join(passwordStore.Path, "ops"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := passwordStore.AddGPGID("ops", "alice"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := passwordStore.RemoveGPGID("ops", "root"); err != nil {
+		t.Fatal(err)
+	}
+
+	log, err := passwordStore.VCS.Log(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Remove GPG id root from \"ops\"", "Add GPG id alice to \"ops\""}, log[:2])
+}
@@ -0,0 +1,216 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// ReencryptOption configures how passwords are reencrypted.
+type ReencryptOption func(*reencryptOptions)
+
+type reencryptOptions struct {
+	concurrency int
+	progress    func(ReencryptProgress)
+}
+
+func newReencryptOptions(opts []ReencryptOption) *reencryptOptions {
+	options := &reencryptOptions{
+		concurrency: runtime.NumCPU(),
+	}
+	for _, fn := range opts {
+		fn(options)
+	}
+	if options.concurrency < 1 {
+		options.concurrency = 1
+	}
+	return options
+}
+
+// WithConcurrency sets how many passwords are reencrypted at the same time.
+// Defaults to the number of CPUs.
+func WithConcurrency(concurrency int) ReencryptOption {
+	return func(opts *reencryptOptions) {
+		opts.concurrency = concurrency
+	}
+}
+
+// WithProgress sets a function that is called after each password is
+// processed. Calls are never concurrent.
+func WithProgress(progress func(ReencryptProgress)) ReencryptOption {
+	return func(opts *reencryptOptions) {
+		opts.progress = progress
+	}
+}
+
+// ReencryptProgress reports that a password was processed.
+type ReencryptProgress struct {
+	Password string // The password that was processed
+	Err      error  // Why the password could not be reencrypted, if it failed
+	Done     int    // The number of passwords processed so far
+	Total    int    // The number of passwords to reencrypt
+}
+
+// ReencryptSummary is the outcome of reencrypting many passwords.
+type ReencryptSummary struct {
+	Total       int              // The number of passwords to reencrypt
+	Reencrypted []string         // The passwords that were reencrypted
+	Failed      map[string]error // The passwords that could not be reencrypted
+}
+
+// ReencryptError is returned when some passwords could not be reencrypted.
+// It unwraps to the error of the first one.
+type ReencryptError struct {
+	Summary *ReencryptSummary
+}
+
+func (e *ReencryptError) Error() string {
+	return fmt.Sprintf(
+		"could not reencrypt %d of %d passwords: %s",
+		len(e.Summary.Failed),
+		e.Summary.Total,
+		e.Unwrap(),
+	)
+}
+
+// Unwrap returns the error of the first password that could not be
+// reencrypted, in lexical order.
+func (e *ReencryptError) Unwrap() error {
+	var passwords []string
+	for password := range e.Summary.Failed {
+		passwords = append(passwords, password)
+	}
+	if len(passwords) == 0 {
+		return nil
+	}
+	sort.Strings(passwords)
+	return e.Summary.Failed[passwords[0]]
+}
+
+// ReencryptPasswords reencrypts passwords to the GPG ids of their directory
+// and commits them. If any of them fails, none of them is changed.
+func (store *PasswordStore) ReencryptPasswords(passwords []string, opts ...ReencryptOption) (*ReencryptSummary, error) {
+	return store.ReencryptPasswordsContext(context.Background(), passwords, opts...)
+}
+
+// ReencryptPasswordsContext is like ReencryptPasswords but stops when ctx is
+// done.
+func (store *PasswordStore) ReencryptPasswordsContext(ctx context.Context, passwords []string, opts ...ReencryptOption) (*ReencryptSummary, error) {
+	options := newReencryptOptions(opts)
+
+	var summary *ReencryptSummary
+
+	batch := store.NewBatch()
+	batch.add(func(ctx context.Context, store *PasswordStore) error {
+		var err error
+		summary, err = store.reencryptPasswords(ctx, passwords, options)
+		return err
+	})
+
+	err := batch.ApplyContext(ctx, fmt.Sprintf("Reencrypt %d passwords", len(passwords)))
+
+	return summary, err
+}
+
+// reencryptPasswords reencrypts passwords using a pool of workers. It does
+// not commit.
+func (store *PasswordStore) reencryptPasswords(ctx context.Context, passwords []string, options *reencryptOptions) (*ReencryptSummary, error) {
+	summary := &ReencryptSummary{
+		Total:  len(passwords),
+		Failed: make(map[string]error),
+	}
+
+	type result struct {
+		password string
+		err      error
+	}
+
+	jobs := make(chan string)
+	results := make(chan result)
+
+	go func() {
+		defer close(jobs)
+		for _, password := range passwords {
+			select {
+			case jobs <- password:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var workers sync.WaitGroup
+	for i := 0; i < options.concurrency; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for password := range jobs {
+				results <- result{
+					password: password,
+					err:      store.ReencryptPasswordContext(ctx, password),
+				}
+			}
+		}()
+	}
+
+	go func() {
+		workers.Wait()
+		close(results)
+	}()
+
+	done := 0
+	for result := range results {
+		// Passwords that were interrupted are neither reencrypted nor failed.
+		if errors.Is(result.err, ctx.Err()) && ctx.Err() != nil {
+			continue
+		}
+
+		if result.err == nil {
+			summary.Reencrypted = append(summary.Reencrypted, result.password)
+		} else {
+			summary.Failed[result.password] = result.err
+		}
+
+		done++
+		if options.progress != nil {
+			options.progress(ReencryptProgress{
+				Password: result.password,
+				Err:      result.err,
+				Done:     done,
+				Total:    summary.Total,
+			})
+		}
+	}
+
+	sort.Strings(summary.Reencrypted)
+
+	if err := ctx.Err(); err != nil {
+		return summary, err
+	}
+
+	if len(summary.Failed) > 0 {
+		return summary, &ReencryptError{Summary: summary}
+	}
+
+	return summary, nil
+}
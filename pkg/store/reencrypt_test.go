@@ -0,0 +1,162 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package store_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aviau/gopass/pkg/store"
+)
+
+// taggingGPGBackend is a GPGBackend whose ciphertexts are the plaintext
+// prefixed with the recipients. It can't decrypt passwords containing "bad".
+type taggingGPGBackend struct{}
+
+func (backend *taggingGPGBackend) Encrypt(content []byte, recipients []string) ([]byte, error) {
+	return append([]byte(strings.Join(recipients, ",")+"\n"), content...), nil
+}
+
+func (backend *taggingGPGBackend) Decrypt(content []byte) ([]byte, error) {
+	if bytes.Contains(content, []byte("bad")) {
+		return nil, errors.New("bad password")
+	}
+	return content[bytes.IndexByte(content, '\n')+1:], nil
+}
+
+// recipients returns the recipients a password was encrypted to.
+func recipients(t *testing.T, storage store.Storage, pwname string) string {
+	content, err := storage.ReadFile(pwname + ".gpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content[:bytes.IndexByte(content, '\n')])
+}
+
+func newTaggingPasswordStore(t *testing.T, passwords ...string) (*store.PasswordStore, store.Storage) {
+	passwordStore, storage := newMemoryPasswordStore(t)
+	passwordStore.GPGBackend = &taggingGPGBackend{}
+
+	if err := passwordStore.SetDirectoryGPGIDs("ops", []string{"ops"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := storage.MkdirAll("dir", 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, pwname := range passwords {
+		if err := passwordStore.InsertPassword(pwname, "password "+pwname); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return passwordStore, storage
+}
+
+func TestReencryptPasswordsProgress(t *testing.T) {
+	var passwords []string
+	for i := 0; i < 20; i++ {
+		passwords = append(passwords, fmt.Sprintf("test%02d.com", i))
+	}
+
+	passwordStore, storage := newTaggingPasswordStore(t, passwords...)
+
+	passwordStore.GPGIDs = []string{"new"}
+
+	var done []int
+	summary, err := passwordStore.ReencryptPasswords(
+		passwords,
+		store.WithConcurrency(4),
+		store.WithProgress(func(progress store.ReencryptProgress) {
+			assert.Nil(t, progress.Err)
+			assert.Equal(t, 20, progress.Total)
+			done = append(done, progress.Done)
+		}),
+	)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 20, summary.Total)
+	assert.Equal(t, passwords, summary.Reencrypted)
+	assert.Empty(t, summary.Failed)
+
+	assert.Len(t, done, 20)
+	assert.Equal(t, 20, done[len(done)-1])
+
+	for _, pwname := range passwords {
+		assert.Equal(t, "new", recipients(t, storage, pwname))
+	}
+}
+
+func TestReencryptPasswordsFailure(t *testing.T) {
+	passwordStore, storage := newTaggingPasswordStore(t, "a.com", "bad.com", "c.com")
+
+	passwordStore.GPGIDs = []string{"new"}
+
+	summary, err := passwordStore.ReencryptPasswords([]string{"a.com", "bad.com", "c.com"})
+
+	var reencryptErr *store.ReencryptError
+	assert.True(t, errors.As(err, &reencryptErr))
+	assert.True(t, errors.Is(err, store.ErrDecrypt))
+
+	assert.Equal(t, []string{"a.com", "c.com"}, summary.Reencrypted)
+	assert.Len(t, summary.Failed, 1)
+	assert.Contains(t, summary.Failed, "bad.com")
+
+	assert.Equal(t, "root", recipients(t, storage, "a.com"), "a.com should have been rolled back")
+	assert.Equal(t, "root", recipients(t, storage, "c.com"), "c.com should have been rolled back")
+}
+
+func TestSetGPGIDsReencrypts(t *testing.T) {
+	passwordStore, storage := newTaggingPasswordStore(t, "a.com", "dir/b.com", "ops/c.com")
+
+	if err := passwordStore.SetGPGIDs([]string{"new1", "new2"}, store.WithConcurrency(2)); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"new1", "new2"}, passwordStore.GPGIDs)
+	assert.Equal(t, "new1,new2", recipients(t, storage, "a.com"))
+	assert.Equal(t, "new1,new2", recipients(t, storage, "dir/b.com"))
+	assert.Equal(t, "ops", recipients(t, storage, "ops/c.com"), "ops has its own .gpg-id")
+
+	if err := passwordStore.SetDirectoryGPGIDs("ops", []string{"ops2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "new1,new2", recipients(t, storage, "a.com"))
+	assert.Equal(t, "ops2", recipients(t, storage, "ops/c.com"))
+}
+
+func TestSetGPGIDsFailure(t *testing.T) {
+	passwordStore, storage := newTaggingPasswordStore(t, "a.com", "bad.com")
+
+	err := passwordStore.SetGPGIDs([]string{"new"})
+	assert.True(t, errors.Is(err, store.ErrDecrypt))
+
+	assert.Equal(t, []string{"root"}, passwordStore.GPGIDs)
+	assert.Equal(t, "root", recipients(t, storage, "a.com"))
+
+	content, err := storage.ReadFile(".gpg-id")
+	assert.Nil(t, err)
+	assert.Equal(t, "root\n", string(content))
+}
@@ -0,0 +1,110 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package store
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+)
+
+// SigningGPGBackend is a GPGBackend that can sign and verify .gpg-id files.
+// It is required when the store has SigningKeys.
+type SigningGPGBackend interface {
+	GPGBackend
+	// Sign returns a detached signature of content made with the first of
+	// keys that has a secret key.
+	Sign(ctx context.Context, content []byte, keys []string) ([]byte, error)
+	// Verify returns the fingerprints of the keys that made the valid
+	// signatures of content. Both the fingerprint of the signing subkey and
+	// of its primary key are returned.
+	Verify(ctx context.Context, content []byte, signature []byte) ([]string, error)
+}
+
+// signGPGIDs returns a detached signature of the content of a .gpg-id file,
+// or nil if the store has no signing keys. The signature must verify with one
+// of the signing keys, as gpg may use another key than the ones it is given.
+func (store *PasswordStore) signGPGIDs(ctx context.Context, content []byte) ([]byte, error) {
+	if len(store.SigningKeys) == 0 {
+		return nil, nil
+	}
+
+	backend, ok := store.GPGBackend.(SigningGPGBackend)
+	if !ok {
+		return nil, fmt.Errorf("could not sign the .gpg-id file: %w", ErrUnsupported)
+	}
+
+	signature, err := backend.Sign(ctx, content, store.SigningKeys)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	} else if err != nil {
+		return nil, fmt.Errorf("could not sign the .gpg-id file: %w", err)
+	}
+
+	if err := store.verifySignature(ctx, backend, content, signature); err != nil {
+		return nil, fmt.Errorf("could not sign the .gpg-id file: %w", err)
+	}
+
+	return signature, nil
+}
+
+// verifyGPGIDs makes sure that the .gpg-id file of a directory is signed by
+// one of the store's signing keys, if it has any. It fails with
+// ErrBadSignature otherwise.
+func (store *PasswordStore) verifyGPGIDs(ctx context.Context, dirname string, content []byte) error {
+	if len(store.SigningKeys) == 0 {
+		return nil
+	}
+
+	backend, ok := store.GPGBackend.(SigningGPGBackend)
+	if !ok {
+		return fmt.Errorf("could not verify the .gpg-id file: %w", ErrUnsupported)
+	}
+
+	signature, err := store.Storage.ReadFile(path.Join(dirname, ".gpg-id.sig"))
+	if os.IsNotExist(err) {
+		return fmt.Errorf("%w: the .gpg-id.sig file is missing", ErrBadSignature)
+	} else if err != nil {
+		return err
+	}
+
+	return store.verifySignature(ctx, backend, content, signature)
+}
+
+// verifySignature fails with ErrBadSignature unless signature is a valid
+// signature of content made by one of the store's signing keys.
+func (store *PasswordStore) verifySignature(ctx context.Context, backend SigningGPGBackend, content []byte, signature []byte) error {
+	fingerprints, err := backend.Verify(ctx, content, signature)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	} else if err != nil {
+		return fmt.Errorf("%w: %s", ErrBadSignature, err)
+	}
+
+	for _, fingerprint := range fingerprints {
+		for _, key := range store.SigningKeys {
+			if strings.EqualFold(fingerprint, key) {
+				return nil
+			}
+		}
+	}
+
+	return ErrBadSignature
+}
@@ -0,0 +1,156 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package store_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aviau/gopass/pkg/store"
+	"github.com/stretchr/testify/assert"
+)
+
+// signingGPGBackend is a taggingGPGBackend whose signatures are the signing
+// key followed by the signed content. Like gpg with its default key, it signs
+// with defaultKey instead of the given keys if it is set.
+type signingGPGBackend struct {
+	taggingGPGBackend
+	defaultKey string
+}
+
+func (backend *signingGPGBackend) Sign(ctx context.Context, content []byte, keys []string) ([]byte, error) {
+	if backend.defaultKey != "" {
+		return append([]byte(backend.defaultKey+"\n"), content...), nil
+	}
+	return append([]byte(keys[0]+"\n"), content...), nil
+}
+
+func (backend *signingGPGBackend) Verify(ctx context.Context, content []byte, signature []byte) ([]string, error) {
+	i := bytes.IndexByte(signature, '\n')
+	if !bytes.Equal(signature[i+1:], content) {
+		return nil, errors.New("bad signature")
+	}
+	return []string{string(signature[:i])}, nil
+}
+
+func newSigningPasswordStore(t *testing.T) (*store.PasswordStore, store.Storage) {
+	passwordStore, storage := newTaggingPasswordStore(t)
+	passwordStore.GPGBackend = &signingGPGBackend{}
+	passwordStore.SigningKeys = []string{"signer"}
+
+	if err := passwordStore.SetGPGIDs([]string{"root"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := passwordStore.SetDirectoryGPGIDs("ops", []string{"ops"}); err != nil {
+		t.Fatal(err)
+	}
+
+	return passwordStore, storage
+}
+
+func TestSigningSignsGPGIDs(t *testing.T) {
+	passwordStore, storage := newSigningPasswordStore(t)
+
+	signature, err := storage.ReadFile("ops/.gpg-id.sig")
+	assert.Nil(t, err)
+	assert.Equal(t, "signer\nops\n", string(signature))
+
+	assert.Nil(t, passwordStore.InsertPassword("test.com", "password"))
+	assert.Nil(t, passwordStore.InsertPassword("ops/test.com", "password"))
+	assert.Equal(t, "ops", recipients(t, storage, "ops/test.com"))
+}
+
+func TestSigningRejectsTamperedGPGIDs(t *testing.T) {
+	passwordStore, storage := newSigningPasswordStore(t)
+
+	if err := storage.WriteFile("ops/.gpg-id", []byte("ops\nattacker\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := passwordStore.InsertPassword("ops/test.com", "password")
+	assert.True(t, errors.Is(err, store.ErrBadSignature))
+
+	_, err = storage.Stat("ops/test.com.gpg")
+	assert.NotNil(t, err, "nothing should be encrypted to an unsigned .gpg-id")
+}
+
+func TestSigningRejectsOtherSigners(t *testing.T) {
+	passwordStore, storage := newSigningPasswordStore(t)
+
+	if err := storage.WriteFile(".gpg-id", []byte("root\nattacker\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.WriteFile(".gpg-id.sig", []byte("attacker\nroot\nattacker\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := passwordStore.InsertPassword("test.com", "password")
+	assert.True(t, errors.Is(err, store.ErrBadSignature))
+}
+
+func TestSigningRejectsMissingSignature(t *testing.T) {
+	passwordStore, storage := newSigningPasswordStore(t)
+
+	if err := storage.Remove("ops/.gpg-id.sig"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := passwordStore.GPGIDsForDirectory("ops")
+	assert.True(t, errors.Is(err, store.ErrBadSignature))
+}
+
+func TestSigningUnsupportedBackend(t *testing.T) {
+	passwordStore, _ := newTaggingPasswordStore(t)
+	passwordStore.SigningKeys = []string{"signer"}
+
+	err := passwordStore.SetDirectoryGPGIDs("dir", []string{"root"})
+	assert.True(t, errors.Is(err, store.ErrUnsupported))
+}
+
+func TestSigningRejectsSignaturesByOtherKeys(t *testing.T) {
+	passwordStore, storage := newSigningPasswordStore(t)
+	passwordStore.GPGBackend = &signingGPGBackend{defaultKey: "other"}
+
+	err := passwordStore.SetDirectoryGPGIDs("dir", []string{"root"})
+	assert.True(t, errors.Is(err, store.ErrBadSignature))
+
+	_, err = storage.Stat("dir/.gpg-id")
+	assert.NotNil(t, err, "the .gpg-id file should not have been written")
+
+	err = passwordStore.SetGPGIDs([]string{"new"})
+	assert.True(t, errors.Is(err, store.ErrBadSignature))
+	assert.Equal(t, []string{"root"}, passwordStore.GPGIDs)
+}
+
+func TestSigningInitRejectsSignaturesByOtherKeys(t *testing.T) {
+	passwordStore := store.NewPasswordStore(t.TempDir())
+	passwordStore.UsesGit = false
+	passwordStore.GPGBackend = &signingGPGBackend{defaultKey: "other"}
+	passwordStore.SigningKeys = []string{"signer"}
+
+	err := passwordStore.Init([]string{"root"})
+	assert.True(t, errors.Is(err, store.ErrBadSignature))
+
+	_, err = os.Stat(filepath.Join(passwordStore.Path, ".gpg-id"))
+	assert.True(t, os.IsNotExist(err), "the .gpg-id file should not have been written")
+}
@@ -0,0 +1,226 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package store
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	gopassio "github.com/aviau/gopass/internal/io"
+)
+
+// Storage is the PasswordStore's storage layer. Names are slash-separated
+// paths relative to the root of the store, the root itself being ".".
+// Errors should behave like the ones of the os package, so that they can be
+// tested with os.IsNotExist.
+type Storage interface {
+	// ReadFile returns the content of a file.
+	ReadFile(name string) ([]byte, error)
+
+	// WriteFile replaces the content of a file, creating it if needed. The
+	// parent directory must exist. The file must never be left partially
+	// written.
+	WriteFile(name string, data []byte, perm os.FileMode) error
+
+	// Stat returns information about a file or directory.
+	Stat(name string) (os.FileInfo, error)
+
+	// Walk walks the tree rooted at name in lexical order, calling fn for
+	// each file or directory with its slash-separated name.
+	Walk(name string, fn filepath.WalkFunc) error
+
+	// MkdirAll creates a directory and all of its missing parents.
+	MkdirAll(name string, perm os.FileMode) error
+
+	// Rename moves a file or directory.
+	Rename(oldname, newname string) error
+
+	// Remove removes a file or an empty directory.
+	Remove(name string) error
+
+	// RemoveAll removes a file or directory and everything it contains.
+	RemoveAll(name string) error
+
+	// Chmod changes the permissions of a file or directory.
+	Chmod(name string, perm os.FileMode) error
+}
+
+// DiskStorage is a Storage on the OS filesystem.
+type DiskStorage struct {
+	root string
+}
+
+// NewDiskStorage returns a DiskStorage rooted at the given directory.
+func NewDiskStorage(root string) *DiskStorage {
+	return &DiskStorage{root: root}
+}
+
+// path returns the OS path of a name. It fails with ErrInvalidName if the
+// path goes through a symlink pointing outside of the root.
+func (s *DiskStorage) path(name string) (string, error) {
+	diskPath := filepath.Join(s.root, filepath.FromSlash(name))
+	if err := s.checkInsideRoot(diskPath); err != nil {
+		return "", err
+	}
+	return diskPath, nil
+}
+
+// checkInsideRoot makes sure that a path does not escape the root once
+// symlinks are resolved. The path itself does not need to exist.
+func (s *DiskStorage) checkInsideRoot(diskPath string) error {
+	root, err := filepath.EvalSymlinks(s.root)
+	if os.IsNotExist(err) {
+		// There is nothing to escape from.
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	// Resolve the longest existing part of the path.
+	resolved := diskPath
+	missing := ""
+	for {
+		evaluated, err := filepath.EvalSymlinks(resolved)
+		if err == nil {
+			resolved = filepath.Join(evaluated, missing)
+			break
+		}
+		if !os.IsNotExist(err) {
+			return err
+		}
+
+		// A dangling symlink could still point outside of the root.
+		if _, err := os.Lstat(resolved); err == nil {
+			return ErrInvalidName
+		}
+
+		parent := filepath.Dir(resolved)
+		if parent == resolved {
+			break
+		}
+		missing = filepath.Join(filepath.Base(resolved), missing)
+		resolved = parent
+	}
+
+	relative, err := filepath.Rel(root, resolved)
+	if err != nil || relative == ".." || strings.HasPrefix(relative, "../") {
+		return ErrInvalidName
+	}
+
+	return nil
+}
+
+// ReadFile implements Storage.
+func (s *DiskStorage) ReadFile(name string) ([]byte, error) {
+	diskPath, err := s.path(name)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadFile(diskPath)
+}
+
+// WriteFile implements Storage.
+func (s *DiskStorage) WriteFile(name string, data []byte, perm os.FileMode) error {
+	diskPath, err := s.path(name)
+	if err != nil {
+		return err
+	}
+	return gopassio.WriteFileAtomic(diskPath, data, perm)
+}
+
+// Stat implements Storage.
+func (s *DiskStorage) Stat(name string) (os.FileInfo, error) {
+	diskPath, err := s.path(name)
+	if err != nil {
+		return nil, err
+	}
+	return os.Stat(diskPath)
+}
+
+// Walk implements Storage.
+func (s *DiskStorage) Walk(name string, fn filepath.WalkFunc) error {
+	diskPath, err := s.path(name)
+	if err != nil {
+		return err
+	}
+
+	return filepath.Walk(diskPath, func(walkPath string, fileInfo os.FileInfo, err error) error {
+		relative, relErr := filepath.Rel(s.root, walkPath)
+		if relErr != nil {
+			return relErr
+		}
+		return fn(filepath.ToSlash(relative), fileInfo, err)
+	})
+}
+
+// MkdirAll implements Storage.
+func (s *DiskStorage) MkdirAll(name string, perm os.FileMode) error {
+	diskPath, err := s.path(name)
+	if err != nil {
+		return err
+	}
+	return os.MkdirAll(diskPath, perm)
+}
+
+// Rename implements Storage.
+func (s *DiskStorage) Rename(oldname, newname string) error {
+	oldPath, err := s.path(oldname)
+	if err != nil {
+		return err
+	}
+
+	newPath, err := s.path(newname)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Rename(oldPath, newPath); err != nil {
+		return err
+	}
+
+	return gopassio.SyncDir(filepath.Dir(newPath))
+}
+
+// Remove implements Storage.
+func (s *DiskStorage) Remove(name string) error {
+	diskPath, err := s.path(name)
+	if err != nil {
+		return err
+	}
+	return os.Remove(diskPath)
+}
+
+// RemoveAll implements Storage.
+func (s *DiskStorage) RemoveAll(name string) error {
+	diskPath, err := s.path(name)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(diskPath)
+}
+
+// Chmod implements Storage.
+func (s *DiskStorage) Chmod(name string, perm os.FileMode) error {
+	diskPath, err := s.path(name)
+	if err != nil {
+		return err
+	}
+	return os.Chmod(diskPath, perm)
+}
@@ -0,0 +1,224 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package store
+
+import (
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// journalStorage is a Storage that records the previous state of every
+// file and directory it changes so that they can be restored. It is safe for
+// concurrent use if the underlying Storage is.
+type journalStorage struct {
+	Storage
+	mu      sync.Mutex
+	entries map[string]*journalEntry
+}
+
+// journalEntry is the state of a file or directory before it was changed.
+type journalEntry struct {
+	existed bool
+	isDir   bool
+	data    []byte
+	mode    os.FileMode
+}
+
+func newJournalStorage(storage Storage) *journalStorage {
+	return &journalStorage{
+		Storage: storage,
+		entries: make(map[string]*journalEntry),
+	}
+}
+
+// record saves the state of name and of everything it contains, unless it
+// was already saved.
+func (s *journalStorage) record(name string) error {
+	name = cleanMemoryName(name)
+
+	// Whatever is inside of a directory that did not exist is removed along
+	// with it.
+	for dir := path.Dir(name); dir != "" && dir != "."; dir = path.Dir(dir) {
+		if entry, ok := s.entries[dir]; ok && !entry.existed {
+			return nil
+		}
+	}
+
+	if _, err := s.Storage.Stat(name); os.IsNotExist(err) {
+		if _, ok := s.entries[name]; !ok {
+			s.entries[name] = &journalEntry{existed: false}
+		}
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	return s.Storage.Walk(name, func(walkName string, fileInfo os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if _, ok := s.entries[walkName]; ok {
+			return nil
+		}
+
+		entry := &journalEntry{
+			existed: true,
+			isDir:   fileInfo.IsDir(),
+			mode:    fileInfo.Mode().Perm(),
+		}
+		if !entry.isDir {
+			if entry.data, err = s.Storage.ReadFile(walkName); err != nil {
+				return err
+			}
+		}
+		s.entries[walkName] = entry
+
+		return nil
+	})
+}
+
+// WriteFile implements Storage.
+func (s *journalStorage) WriteFile(name string, data []byte, perm os.FileMode) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.record(name); err != nil {
+		return err
+	}
+	return s.Storage.WriteFile(name, data, perm)
+}
+
+// MkdirAll implements Storage.
+func (s *journalStorage) MkdirAll(name string, perm os.FileMode) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for dir := cleanMemoryName(name); dir != "" && dir != "."; dir = path.Dir(dir) {
+		if err := s.record(dir); err != nil {
+			return err
+		}
+	}
+	return s.Storage.MkdirAll(name, perm)
+}
+
+// Rename implements Storage.
+func (s *journalStorage) Rename(oldname, newname string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.record(oldname); err != nil {
+		return err
+	}
+	if err := s.record(newname); err != nil {
+		return err
+	}
+	return s.Storage.Rename(oldname, newname)
+}
+
+// Remove implements Storage.
+func (s *journalStorage) Remove(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.record(name); err != nil {
+		return err
+	}
+	return s.Storage.Remove(name)
+}
+
+// RemoveAll implements Storage.
+func (s *journalStorage) RemoveAll(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.record(name); err != nil {
+		return err
+	}
+	return s.Storage.RemoveAll(name)
+}
+
+// changed returns the names that were changed and that exist now or
+// existed before, in lexical order.
+func (s *journalStorage) changed() []string {
+	var names []string
+	for name, entry := range s.entries {
+		if _, err := s.Storage.Stat(name); entry.existed || err == nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// rollback restores the recorded state of every changed file and directory.
+func (s *journalStorage) rollback() error {
+	var names []string
+	for name := range s.entries {
+		names = append(names, name)
+	}
+
+	// Remove what did not exist, deepest first...
+	sort.Slice(names, func(i, j int) bool {
+		return strings.Count(names[i], "/") > strings.Count(names[j], "/")
+	})
+	for _, name := range names {
+		if !s.entries[name].existed {
+			if err := s.Storage.RemoveAll(name); err != nil {
+				return err
+			}
+		}
+	}
+
+	// ...then restore what existed, parents first.
+	sort.Strings(names)
+	for _, name := range names {
+		entry := s.entries[name]
+		if !entry.existed {
+			continue
+		}
+
+		if entry.isDir {
+			if fileInfo, err := s.Storage.Stat(name); err == nil && !fileInfo.IsDir() {
+				if err := s.Storage.Remove(name); err != nil {
+					return err
+				}
+			}
+			if err := s.Storage.MkdirAll(name, entry.mode); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if fileInfo, err := s.Storage.Stat(name); err == nil && fileInfo.IsDir() {
+			if err := s.Storage.RemoveAll(name); err != nil {
+				return err
+			}
+		}
+		if err := s.Storage.MkdirAll(path.Dir(name), 0700); err != nil {
+			return err
+		}
+		if err := s.Storage.WriteFile(name, entry.data, entry.mode); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,309 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package store
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryStorage is a Storage that keeps everything in memory. It is safe for
+// concurrent use.
+type MemoryStorage struct {
+	mu    sync.RWMutex
+	files map[string]*memoryFile
+}
+
+type memoryFile struct {
+	data    []byte
+	mode    os.FileMode
+	modTime time.Time
+}
+
+// NewMemoryStorage returns an empty MemoryStorage.
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		files: map[string]*memoryFile{
+			".": {mode: os.ModeDir | 0700, modTime: time.Now()},
+		},
+	}
+}
+
+// memoryFileInfo implements os.FileInfo for a memoryFile.
+type memoryFileInfo struct {
+	name string
+	file *memoryFile
+}
+
+func (fi *memoryFileInfo) Name() string       { return path.Base(fi.name) }
+func (fi *memoryFileInfo) Size() int64        { return int64(len(fi.file.data)) }
+func (fi *memoryFileInfo) Mode() os.FileMode  { return fi.file.mode }
+func (fi *memoryFileInfo) ModTime() time.Time { return fi.file.modTime }
+func (fi *memoryFileInfo) IsDir() bool        { return fi.file.mode.IsDir() }
+func (fi *memoryFileInfo) Sys() interface{}   { return nil }
+
+func cleanMemoryName(name string) string {
+	return strings.TrimPrefix(path.Clean("/"+name), "/")
+}
+
+func memoryPathError(op, name string, err error) error {
+	return &os.PathError{Op: op, Path: name, Err: err}
+}
+
+// parentExists returns whether or not the parent directory of a cleaned
+// name exists. The caller must hold the lock.
+func (s *MemoryStorage) parentExists(name string) bool {
+	parent, ok := s.files[s.parentName(name)]
+	return ok && parent.mode.IsDir()
+}
+
+func (s *MemoryStorage) parentName(name string) string {
+	return path.Dir(name)
+}
+
+func (s *MemoryStorage) key(name string) string {
+	name = cleanMemoryName(name)
+	if name == "" {
+		return "."
+	}
+	return name
+}
+
+// ReadFile implements Storage.
+func (s *MemoryStorage) ReadFile(name string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	file, ok := s.files[s.key(name)]
+	if !ok {
+		return nil, memoryPathError("open", name, os.ErrNotExist)
+	}
+	if file.mode.IsDir() {
+		return nil, memoryPathError("read", name, errIsDirectory)
+	}
+
+	return append([]byte(nil), file.data...), nil
+}
+
+// WriteFile implements Storage.
+func (s *MemoryStorage) WriteFile(name string, data []byte, perm os.FileMode) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := s.key(name)
+	if !s.parentExists(key) {
+		return memoryPathError("open", name, os.ErrNotExist)
+	}
+	if file, ok := s.files[key]; ok && file.mode.IsDir() {
+		return memoryPathError("open", name, errIsDirectory)
+	}
+
+	s.files[key] = &memoryFile{
+		data:    append([]byte(nil), data...),
+		mode:    perm.Perm(),
+		modTime: time.Now(),
+	}
+
+	return nil
+}
+
+// Stat implements Storage.
+func (s *MemoryStorage) Stat(name string) (os.FileInfo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key := s.key(name)
+	file, ok := s.files[key]
+	if !ok {
+		return nil, memoryPathError("stat", name, os.ErrNotExist)
+	}
+
+	return &memoryFileInfo{name: key, file: file}, nil
+}
+
+// children returns the sorted names of the direct children of a directory.
+// The caller must hold the lock.
+func (s *MemoryStorage) children(dir string) []string {
+	var children []string
+	for name := range s.files {
+		if name != "." && path.Dir(name) == dir {
+			children = append(children, name)
+		}
+	}
+	sort.Strings(children)
+	return children
+}
+
+// Walk implements Storage.
+func (s *MemoryStorage) Walk(name string, fn filepath.WalkFunc) error {
+	s.mu.RLock()
+	key := s.key(name)
+	file, ok := s.files[key]
+	s.mu.RUnlock()
+
+	if !ok {
+		return fn(key, nil, memoryPathError("lstat", name, os.ErrNotExist))
+	}
+
+	err := s.walk(key, &memoryFileInfo{name: key, file: file}, fn)
+	if err == filepath.SkipDir {
+		return nil
+	}
+	return err
+}
+
+func (s *MemoryStorage) walk(name string, fileInfo os.FileInfo, fn filepath.WalkFunc) error {
+	if err := fn(name, fileInfo, nil); err != nil || !fileInfo.IsDir() {
+		return err
+	}
+
+	s.mu.RLock()
+	var infos []os.FileInfo
+	for _, child := range s.children(name) {
+		infos = append(infos, &memoryFileInfo{name: child, file: s.files[child]})
+	}
+	s.mu.RUnlock()
+
+	for _, info := range infos {
+		childName := path.Join(name, info.Name())
+		if err := s.walk(childName, info, fn); err != nil {
+			if err == filepath.SkipDir && info.IsDir() {
+				continue
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MkdirAll implements Storage.
+func (s *MemoryStorage) MkdirAll(name string, perm os.FileMode) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var missing []string
+	for dir := s.key(name); dir != "."; dir = path.Dir(dir) {
+		if file, ok := s.files[dir]; ok {
+			if !file.mode.IsDir() {
+				return memoryPathError("mkdir", dir, errNotDirectory)
+			}
+			break
+		}
+		missing = append(missing, dir)
+	}
+
+	for _, dir := range missing {
+		s.files[dir] = &memoryFile{mode: os.ModeDir | perm.Perm(), modTime: time.Now()}
+	}
+
+	return nil
+}
+
+// Rename implements Storage.
+func (s *MemoryStorage) Rename(oldname, newname string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	oldKey := s.key(oldname)
+	newKey := s.key(newname)
+
+	file, ok := s.files[oldKey]
+	if !ok || oldKey == "." {
+		return &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: os.ErrNotExist}
+	}
+	if !s.parentExists(newKey) {
+		return &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: os.ErrNotExist}
+	}
+	if newKey == oldKey || strings.HasPrefix(newKey, oldKey+"/") {
+		return &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: os.ErrInvalid}
+	}
+	if existing, ok := s.files[newKey]; ok && (existing.mode.IsDir() || file.mode.IsDir()) {
+		if !existing.mode.IsDir() || len(s.children(newKey)) > 0 {
+			return &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: os.ErrExist}
+		}
+	}
+
+	for name, f := range s.files {
+		if name == oldKey {
+			delete(s.files, name)
+			s.files[newKey] = f
+		} else if strings.HasPrefix(name, oldKey+"/") {
+			delete(s.files, name)
+			s.files[newKey+strings.TrimPrefix(name, oldKey)] = f
+		}
+	}
+
+	return nil
+}
+
+// Remove implements Storage.
+func (s *MemoryStorage) Remove(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := s.key(name)
+	file, ok := s.files[key]
+	if !ok || key == "." {
+		return memoryPathError("remove", name, os.ErrNotExist)
+	}
+	if file.mode.IsDir() && len(s.children(key)) > 0 {
+		return memoryPathError("remove", name, errDirectoryNotEmpty)
+	}
+
+	delete(s.files, key)
+
+	return nil
+}
+
+// RemoveAll implements Storage.
+func (s *MemoryStorage) RemoveAll(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := s.key(name)
+	for existing := range s.files {
+		if existing == "." {
+			continue
+		}
+		if key == "." || existing == key || strings.HasPrefix(existing, key+"/") {
+			delete(s.files, existing)
+		}
+	}
+
+	return nil
+}
+
+// Chmod implements Storage.
+func (s *MemoryStorage) Chmod(name string, perm os.FileMode) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, ok := s.files[s.key(name)]
+	if !ok {
+		return memoryPathError("chmod", name, os.ErrNotExist)
+	}
+	file.mode = file.mode&os.ModeType | perm.Perm()
+
+	return nil
+}
@@ -0,0 +1,136 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package store_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aviau/gopass/pkg/store"
+)
+
+func newMemoryPasswordStore(t *testing.T) (*store.PasswordStore, *store.MemoryStorage) {
+	storage := store.NewMemoryStorage()
+
+	passwordStore := store.NewPasswordStore(filepath.Join(t.TempDir(), "store"))
+	passwordStore.UsesGit = false
+	passwordStore.GPGBackend = &recordingGPGBackend{}
+	passwordStore.Storage = storage
+
+	if err := passwordStore.Init([]string{"root"}); err != nil {
+		t.Fatal(err)
+	}
+
+	return passwordStore, storage
+}
+
+func TestMemoryStorageDoesNotTouchDisk(t *testing.T) {
+	passwordStore, storage := newMemoryPasswordStore(t)
+
+	if err := passwordStore.SetDirectoryGPGIDs("ops", []string{"ops"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := storage.MkdirAll("ops/servers", 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, pwname := range []string{"test.com", "ops/a.com", "ops/servers/b.com"} {
+		if err := passwordStore.InsertPassword(pwname, "password "+pwname); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	content, err := storage.ReadFile("ops/.gpg-id")
+	assert.Nil(t, err)
+	assert.Equal(t, "ops\n", string(content))
+
+	decryptedPassword, err := passwordStore.GetPassword("ops/servers/b.com")
+	assert.Nil(t, err)
+	assert.Equal(t, "password ops/servers/b.com", decryptedPassword)
+
+	assert.Equal(
+		t,
+		[]string{"ops/a.com", "ops/servers/b.com", "test.com"},
+		passwordStore.GetPasswordsList(),
+	)
+
+	_, err = os.Stat(passwordStore.Path)
+	assert.True(t, os.IsNotExist(err), "the store should not have been created on disk")
+}
+
+func TestMemoryStorageMoveCopyAndRemove(t *testing.T) {
+	passwordStore, storage := newMemoryPasswordStore(t)
+
+	if err := storage.MkdirAll("dir/sub", 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, pwname := range []string{"test.com", "dir/a.com", "dir/sub/b.com"} {
+		if err := passwordStore.InsertPassword(pwname, "password"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assert.Nil(t, passwordStore.MovePassword("test.com", "dir/"))
+	assert.Nil(t, passwordStore.CopyDirectory("dir", "copy"))
+	assert.Nil(t, passwordStore.MoveDirectory("dir", "moved"))
+	assert.Nil(t, passwordStore.RemovePassword("copy/a.com"))
+
+	assert.Equal(
+		t,
+		[]string{
+			"copy/sub/b.com",
+			"copy/test.com",
+			"moved/a.com",
+			"moved/sub/b.com",
+			"moved/test.com",
+		},
+		passwordStore.GetPasswordsList(),
+	)
+
+	assert.Nil(t, passwordStore.RemoveDirectory("copy"))
+
+	containsDirectory, _ := passwordStore.ContainsDirectory("copy")
+	assert.False(t, containsDirectory, "copy should have been removed")
+
+	_, err := passwordStore.GetPassword("dir/a.com")
+	assert.True(t, errors.Is(err, store.ErrNotFound))
+}
+
+func TestMemoryStorageWriteFileMissingParent(t *testing.T) {
+	storage := store.NewMemoryStorage()
+
+	err := storage.WriteFile("dir/test.com.gpg", []byte("password"), 0600)
+	assert.True(t, os.IsNotExist(err))
+
+	assert.Nil(t, storage.MkdirAll("dir/sub", 0700))
+	assert.Nil(t, storage.WriteFile("dir/sub/test.com.gpg", []byte("password"), 0600))
+
+	fileInfo, err := storage.Stat("dir/sub/test.com.gpg")
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), fileInfo.Mode())
+	assert.Equal(t, int64(8), fileInfo.Size())
+
+	err = storage.Remove("dir")
+	assert.NotNil(t, err, "non-empty directories should not be removed")
+}
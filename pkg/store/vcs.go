@@ -0,0 +1,60 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package store
+
+import "context"
+
+// VCS is the PasswordStore's version control system. Paths are relative to
+// the root of the store. Commands stop when ctx is done.
+type VCS interface {
+	// Init creates the repository.
+	Init(ctx context.Context) error
+
+	// Add stages the changes made to paths, including removals.
+	Add(ctx context.Context, paths ...string) error
+
+	// Commit records the staged changes. It does nothing if no changes are
+	// staged.
+	Commit(ctx context.Context, message string) error
+
+	// Log returns the commits that touched paths, or every commit if no
+	// paths are given, newest first.
+	Log(ctx context.Context, paths ...string) ([]string, error)
+
+	// Status returns the paths that have uncommitted changes.
+	Status(ctx context.Context) ([]string, error)
+}
+
+// NoopVCS is a VCS that does nothing, for stores that are not under
+// version control.
+type NoopVCS struct{}
+
+// Init implements VCS.
+func (NoopVCS) Init(ctx context.Context) error { return nil }
+
+// Add implements VCS.
+func (NoopVCS) Add(ctx context.Context, paths ...string) error { return nil }
+
+// Commit implements VCS.
+func (NoopVCS) Commit(ctx context.Context, message string) error { return nil }
+
+// Log implements VCS.
+func (NoopVCS) Log(ctx context.Context, paths ...string) ([]string, error) { return nil, nil }
+
+// Status implements VCS.
+func (NoopVCS) Status(ctx context.Context) ([]string, error) { return nil, nil }
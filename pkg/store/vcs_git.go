@@ -0,0 +1,213 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package store
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// GitVCS is a VCS that runs the git command.
+type GitVCS struct {
+	workTree string
+	gitDir   string
+	stdout   io.Writer
+	stderr   io.Writer
+}
+
+// NewGitVCS returns a GitVCS for the given work tree and git directory. The
+// output of git commands that are not parsed is written to stdout and
+// stderr, which may be nil to discard it.
+func NewGitVCS(workTree, gitDir string, stdout, stderr io.Writer) *GitVCS {
+	return &GitVCS{
+		workTree: workTree,
+		gitDir:   gitDir,
+		stdout:   stdout,
+		stderr:   stderr,
+	}
+}
+
+// command returns a git command running in the work tree.
+func (vcs *GitVCS) command(ctx context.Context, args ...string) *exec.Cmd {
+	gitArgs := []string{
+		"--git-dir=" + vcs.gitDir,
+		"--work-tree=" + vcs.workTree}
+
+	git := exec.CommandContext(ctx, "git", append(gitArgs, args...)...)
+	git.Dir = vcs.workTree
+
+	return git
+}
+
+// run runs a git command, writing its output to the VCS's writers. The
+// error includes what git wrote on stderr.
+func (vcs *GitVCS) run(ctx context.Context, args ...string) error {
+	_, err := vcs.output(ctx, vcs.stdout, args...)
+	return err
+}
+
+// output runs a git command and returns its output. It is also written to
+// stdout if it is not nil.
+func (vcs *GitVCS) output(ctx context.Context, stdout io.Writer, args ...string) ([]byte, error) {
+	var outBuf, errBuf bytes.Buffer
+
+	git := vcs.command(ctx, args...)
+	git.Stdout = &outBuf
+	git.Stderr = &errBuf
+	if stdout != nil {
+		git.Stdout = io.MultiWriter(&outBuf, stdout)
+	}
+	if vcs.stderr != nil {
+		git.Stderr = io.MultiWriter(&errBuf, vcs.stderr)
+	}
+
+	if err := git.Run(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		if message := strings.TrimSpace(errBuf.String()); message != "" {
+			return nil, fmt.Errorf("git %s: %w: %s", args[0], err, message)
+		}
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+
+	return outBuf.Bytes(), nil
+}
+
+// Init implements VCS.
+func (vcs *GitVCS) Init(ctx context.Context) error {
+	return vcs.run(ctx, "init")
+}
+
+// Add implements VCS. Anything else that was staged is unstaged first, as
+// Commit records the whole index.
+func (vcs *GitVCS) Add(ctx context.Context, paths ...string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+
+	if err := vcs.run(ctx, "reset", "--quiet"); err != nil {
+		return err
+	}
+
+	paths, err := vcs.knownPaths(ctx, paths)
+	if err != nil || len(paths) == 0 {
+		return err
+	}
+
+	return vcs.run(ctx, append([]string{"add", "--all", "--"}, paths...)...)
+}
+
+// knownPaths returns the paths that exist in the work tree or in the index.
+// git add fails on the others, such as untracked files that were removed.
+func (vcs *GitVCS) knownPaths(ctx context.Context, paths []string) ([]string, error) {
+	out, err := vcs.output(ctx, nil, append([]string{"ls-files", "-z", "--"}, paths...)...)
+	if err != nil {
+		return nil, err
+	}
+	tracked := strings.Split(string(out), "\x00")
+
+	var known []string
+	for _, name := range paths {
+		if _, err := os.Lstat(filepath.Join(vcs.workTree, name)); err == nil {
+			known = append(known, name)
+			continue
+		}
+		for _, trackedName := range tracked {
+			if trackedName == name || strings.HasPrefix(trackedName, name+"/") {
+				known = append(known, name)
+				break
+			}
+		}
+	}
+
+	return known, nil
+}
+
+// Commit implements VCS.
+func (vcs *GitVCS) Commit(ctx context.Context, message string) error {
+	// git diff exits with 1 when there are staged changes.
+	err := vcs.command(ctx, "diff", "--cached", "--quiet").Run()
+	if err == nil {
+		return nil
+	}
+
+	var exitErr *exec.ExitError
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	} else if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+		return fmt.Errorf("git diff: %w", err)
+	}
+
+	return vcs.run(ctx, "commit", "-m", message)
+}
+
+// Log implements VCS.
+func (vcs *GitVCS) Log(ctx context.Context, paths ...string) ([]string, error) {
+	args := append([]string{"log", "--format=%s", "--"}, paths...)
+
+	out, err := vcs.output(ctx, nil, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return splitLines(out), nil
+}
+
+// Status implements VCS.
+func (vcs *GitVCS) Status(ctx context.Context) ([]string, error) {
+	out, err := vcs.output(ctx, nil, "status", "--porcelain", "-z", "--untracked-files=all")
+	if err != nil {
+		return nil, err
+	}
+
+	// Entries are formatted as "XY path", renames and copies being
+	// followed by an extra entry with the original path.
+	var paths []string
+	entries := strings.Split(string(out), "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
+			continue
+		}
+		paths = append(paths, entry[3:])
+		if entry[0] == 'R' || entry[0] == 'C' {
+			i++
+		}
+	}
+
+	return paths, nil
+}
+
+// splitLines returns the non-empty lines of a command's output.
+func splitLines(out []byte) []string {
+	var lines []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
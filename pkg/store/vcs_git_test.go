@@ -0,0 +1,159 @@
+//    Copyright (C) 2026 Alexandre Viau <alexandre@alexandreviau.net>
+//
+//    This file is part of gopass.
+//
+//    gopass is free software: you can redistribute it and/or modify
+//    it under the terms of the GNU General Public License as published by
+//    the Free Software Foundation, either version 3 of the License, or
+//    (at your option) any later version.
+//
+//    gopass is distributed in the hope that it will be useful,
+//    but WITHOUT ANY WARRANTY; without even the implied warranty of
+//    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//    GNU General Public License for more details.
+//
+//    You should have received a copy of the GNU General Public License
+//    along with gopass.  If not, see <http://www.gnu.org/licenses/>.
+
+package store_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aviau/gopass/pkg/store"
+)
+
+func newGitPasswordStore(t *testing.T) (*store.PasswordStore, *bytes.Buffer) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	t.Setenv("GIT_AUTHOR_NAME", "gopass")
+	t.Setenv("GIT_AUTHOR_EMAIL", "gopass@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "gopass")
+	t.Setenv("GIT_COMMITTER_EMAIL", "gopass@example.com")
+
+	var output bytes.Buffer
+
+	passwordStore := store.NewPasswordStore(t.TempDir())
+	passwordStore.GPGBackend = &recordingGPGBackend{}
+	passwordStore.VCS = store.NewGitVCS(passwordStore.Path, passwordStore.GitDir, &output, ioutil.Discard)
+
+	if err := passwordStore.Init([]string{"root"}); err != nil {
+		t.Fatal(err)
+	}
+
+	return passwordStore, &output
+}
+
+func TestGitVCSCommits(t *testing.T) {
+	passwordStore, output := newGitPasswordStore(t)
+
+	if err := passwordStore.InsertPassword("test.com", "password"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := passwordStore.MovePassword("test.com", "moved.com"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := passwordStore.RemovePassword("moved.com"); err != nil {
+		t.Fatal(err)
+	}
+
+	log, err := passwordStore.VCS.Log(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		[]string{
+			"removed password \"moved.com\" from the store",
+			"moved Password \"test.com\" to \"moved.com\"",
+			"added password \"test.com\"",
+			"initial commit",
+		},
+		log,
+	)
+
+	status, err := passwordStore.VCS.Status(context.Background())
+	assert.Nil(t, err)
+	assert.Empty(t, status)
+
+	assert.Contains(t, output.String(), "initial commit", "git output should be written to the VCS writers")
+}
+
+func TestGitVCSCommitWithoutChanges(t *testing.T) {
+	passwordStore, _ := newGitPasswordStore(t)
+
+	assert.Nil(t, passwordStore.AddAndCommit("nothing changed", ".gpg-id"))
+
+	log, err := passwordStore.VCS.Log(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"initial commit"}, log)
+}
+
+func TestGitVCSCommitsOnlyItsPaths(t *testing.T) {
+	passwordStore, _ := newGitPasswordStore(t)
+
+	if err := ioutil.WriteFile(filepath.Join(passwordStore.Path, "unrelated.txt"), []byte("unrelated"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	git := exec.Command("git", "add", "unrelated.txt")
+	git.Dir = passwordStore.Path
+	if err := git.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := passwordStore.InsertPassword("test.com", "password"); err != nil {
+		t.Fatal(err)
+	}
+
+	log, err := passwordStore.VCS.Log(context.Background(), "unrelated.txt")
+	assert.Nil(t, err)
+	assert.Empty(t, log, "unrelated.txt should not have been committed")
+}
+
+func TestGitVCSRemoveUntrackedPassword(t *testing.T) {
+	passwordStore, _ := newGitPasswordStore(t)
+
+	if err := ioutil.WriteFile(filepath.Join(passwordStore.Path, "untracked.com.gpg"), []byte("password"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, passwordStore.RemovePassword("untracked.com"))
+
+	log, err := passwordStore.VCS.Log(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"initial commit"}, log)
+}
+
+func TestGitVCSStatus(t *testing.T) {
+	passwordStore, _ := newGitPasswordStore(t)
+
+	if err := ioutil.WriteFile(filepath.Join(passwordStore.Path, "stray.gpg"), []byte("stray"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := passwordStore.VCS.Status(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"stray.gpg"}, status)
+}
+
+func TestGitVCSErrors(t *testing.T) {
+	passwordStore, _ := newGitPasswordStore(t)
+
+	if err := os.RemoveAll(passwordStore.GitDir); err != nil {
+		t.Fatal(err)
+	}
+
+	err := passwordStore.InsertPassword("test.com", "password")
+	assert.NotNil(t, err, "git failures should be returned")
+}